@@ -0,0 +1,96 @@
+// tests/sample_project2/cli.go
+package main
+
+import (
+	"flag"
+	"fmt"
+	"slices"
+	"sourcelens/sampleproject2/config"
+	"sourcelens/sampleproject2/report"
+	"strings"
+)
+
+// Modes selectable with -mode.
+const (
+	modeProcess = "process"
+	modeStats   = "stats"
+)
+
+// cliOptions holds the settings parsed from the command line.
+type cliOptions struct {
+	// ConfigFlags holds only the configuration flags that were explicitly set,
+	// keyed for config.Resolve.
+	ConfigFlags     map[string]string
+	DryRun          bool
+	ContinueOnError bool
+	Prioritize      bool
+	Checkpoint      string
+	Report          string
+	RequireItems    int
+	SkipProcessed   bool
+	Mode            string
+}
+
+// configFlagNames are the flags forwarded to config.Resolve.
+var configFlagNames = map[string]bool{
+	config.FlagConfigFile: true,
+	config.FlagDataPath:   true,
+	config.FlagThreshold:  true,
+	config.FlagLogLevel:   true,
+	config.FlagLogFile:    true,
+}
+
+// parseFlags parses args (without the program name).
+// -h and -help print usage and return flag.ErrHelp; other errors are printed
+// to stderr together with the usage before being returned.
+func parseFlags(name string, args []string) (*cliOptions, error) {
+	defaults := config.Default()
+	opts := &cliOptions{ConfigFlags: map[string]string{}}
+
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.String(config.FlagConfigFile, "", "path to a .json or .yaml config file (overrides "+config.EnvConfigFile+")")
+	fs.String(config.FlagDataPath, defaults.DataPath, "path to the items data file")
+	fs.Int(config.FlagThreshold, defaults.Threshold, "processing threshold for item values")
+	fs.String(config.FlagLogLevel, defaults.LogLevel, "log level: DEBUG, INFO, WARN or ERROR")
+	fs.String(config.FlagLogFile, "", "append log output to this file instead of stderr")
+	fs.BoolVar(&opts.DryRun, "dry-run", false, "load and process items but do not save them")
+	fs.BoolVar(&opts.ContinueOnError, "continue-on-error", true, "keep processing after an item fails; use -continue-on-error=false to stop at the first failure")
+	fs.BoolVar(&opts.Prioritize, "prioritize", false, "process the highest-value items first")
+	fs.StringVar(&opts.Checkpoint, "checkpoint", "", "record progress in this file and resume from it after a crash")
+	fs.BoolVar(&opts.SkipProcessed, "skip-processed", false, "leave items that are already processed untouched")
+	fs.IntVar(&opts.RequireItems, "require-items", 0, "fail if fewer than this many items are loaded")
+	fs.StringVar(&opts.Report, "report", "", "after the run, print a summary report to stdout: text, json or markdown")
+	fs.StringVar(&opts.Mode, "mode", modeProcess, "what to do: process (run the pipeline) or stats (print item statistics only)")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: %s [flags]\n\nProcesses items from a data file and saves the results.\n", name)
+		fmt.Fprintf(fs.Output(), "Settings are taken from flags, then SOURCELENS_* environment variables, then the config file, then the defaults.\n\nFlags:\n")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if fs.NArg() > 0 {
+		err := fmt.Errorf("unexpected arguments: %v", fs.Args())
+		fmt.Fprintln(fs.Output(), err)
+		fs.Usage()
+		return nil, err
+	}
+	if opts.Mode != modeProcess && opts.Mode != modeStats {
+		err := fmt.Errorf("invalid -mode %q: want %s or %s", opts.Mode, modeProcess, modeStats)
+		fmt.Fprintln(fs.Output(), err)
+		return nil, err
+	}
+	if opts.Report != "" && !slices.Contains(report.Formats, opts.Report) {
+		err := fmt.Errorf("invalid -report %q: want one of %s", opts.Report, strings.Join(report.Formats, ", "))
+		fmt.Fprintln(fs.Output(), err)
+		return nil, err
+	}
+
+	fs.Visit(func(f *flag.Flag) {
+		if configFlagNames[f.Name] {
+			opts.ConfigFlags[f.Name] = f.Value.String()
+		}
+	})
+	return opts, nil
+}
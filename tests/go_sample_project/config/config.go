@@ -1,28 +1,87 @@
 // tests/sample_project2/config/config.go
 package config
 
-import "fmt"
+import (
+	"log/slog"
+	"os"
+	"strconv"
+)
 
 // Constants for Configuration (un-exported)
 const (
-	dataFilePath       = "data/items.json"
+	dataFilePath        = "data/items.json"
 	processingThreshold = 100
-	logLevel           = "INFO"
+	logLevel            = "INFO"
+)
+
+// Environment variables that override the compiled-in defaults.
+const (
+	EnvDataPath  = "SOURCELENS_DATA_PATH"
+	EnvThreshold = "SOURCELENS_THRESHOLD"
+	EnvLogLevel  = "SOURCELENS_LOG_LEVEL"
+	EnvLogFile   = "SOURCELENS_LOG_FILE"
+	// EnvConfigFile names a configuration file for Resolve to load.
+	EnvConfigFile = "SOURCELENS_CONFIG"
 )
 
+// Effective returns the active Config with environment variable overrides applied.
+// An unparsable SOURCELENS_THRESHOLD is ignored with a logged warning.
+func Effective() *Config {
+	cfg := *current()
+	applyEnv(&cfg)
+	return &cfg
+}
+
+// applyEnv overwrites cfg fields with any SOURCELENS_* environment variables that are set.
+func applyEnv(cfg *Config) {
+	if v, ok := os.LookupEnv(EnvDataPath); ok && v != "" {
+		cfg.DataPath = v
+	}
+	if v, ok := os.LookupEnv(EnvThreshold); ok && v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil {
+			pkgLogger().Warn("Config: ignoring invalid threshold override", "env", EnvThreshold, "value", v, "using", cfg.Threshold, "error", err)
+		} else {
+			cfg.Threshold = parsed
+		}
+	}
+	if v, ok := os.LookupEnv(EnvLogLevel); ok && v != "" {
+		cfg.LogLevel = v
+	}
+	if v, ok := os.LookupEnv(EnvLogFile); ok && v != "" {
+		cfg.LogFile = v
+	}
+}
+
 // GetDataPath returns the configured path for the data file.
+// SOURCELENS_DATA_PATH takes precedence over the active Config when set.
 func GetDataPath() string {
-	fmt.Printf("Config: Providing data file path: %s\n", dataFilePath)
-	return dataFilePath
+	path := Effective().DataPath
+	pkgLogger().Debug("Config: providing data file path", "path", path)
+	return path
 }
 
 // GetThreshold returns the configured processing threshold.
+// SOURCELENS_THRESHOLD takes precedence over the active Config when set to a valid integer.
 func GetThreshold() int {
-	fmt.Printf("Config: Providing processing threshold: %d\n", processingThreshold)
-	return processingThreshold
+	threshold := Effective().Threshold
+	pkgLogger().Debug("Config: providing processing threshold", "threshold", threshold)
+	return threshold
 }
 
 // GetLogLevel returns the configured logging level.
+// SOURCELENS_LOG_LEVEL takes precedence over the active Config when set.
 func GetLogLevel() string {
-    return logLevel
-}
\ No newline at end of file
+	return Effective().LogLevel
+}
+
+// GetSlogLevel maps the configured logging level onto a slog.Level.
+// Unrecognized values fall back to slog.LevelInfo.
+func GetSlogLevel() slog.Level {
+	return Effective().SlogLevel()
+}
+
+// Validate checks the effective configuration and reports every problem found.
+func Validate() error {
+	return Effective().Validate()
+}
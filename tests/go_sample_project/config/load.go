@@ -0,0 +1,201 @@
+// tests/sample_project2/config/load.go
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Config holds the application settings that can be supplied from a file.
+type Config struct {
+	DataPath  string `json:"data_path"`
+	Threshold int    `json:"threshold"`
+	LogLevel  string `json:"log_level"`
+	// LogFile is the file log output is appended to. Empty means stderr.
+	LogFile string `json:"log_file"`
+}
+
+// ErrInvalidThreshold is wrapped by the errors returned for a negative or
+// unparsable threshold, so callers can detect them with errors.Is.
+var ErrInvalidThreshold = errors.New("invalid threshold")
+
+// validLogLevels lists the accepted LogLevel values.
+var validLogLevels = []string{"DEBUG", "INFO", "WARN", "ERROR"}
+
+var (
+	activeMu sync.RWMutex
+	active   *Config
+)
+
+// Default returns a Config populated with the built-in defaults.
+func Default() *Config {
+	return &Config{
+		DataPath:  dataFilePath,
+		Threshold: processingThreshold,
+		LogLevel:  logLevel,
+	}
+}
+
+// Validate checks that Threshold is non-negative, DataPath is set and LogLevel
+// is one of DEBUG, INFO, WARN or ERROR (case-insensitive). All violations are
+// returned together as a single joined error.
+func (c *Config) Validate() error {
+	var errs []error
+	if c.Threshold < 0 {
+		errs = append(errs, fmt.Errorf("%w: must be >= 0, got %d", ErrInvalidThreshold, c.Threshold))
+	}
+	if strings.TrimSpace(c.DataPath) == "" {
+		errs = append(errs, errors.New("data path must not be empty"))
+	}
+	if !isValidLogLevel(c.LogLevel) {
+		errs = append(errs, fmt.Errorf("log level must be one of %s, got %q", strings.Join(validLogLevels, "/"), c.LogLevel))
+	}
+	return errors.Join(errs...)
+}
+
+// SlogLevel maps LogLevel onto a slog.Level. Unrecognized values fall back to slog.LevelInfo.
+func (c *Config) SlogLevel() slog.Level {
+	switch strings.ToUpper(c.LogLevel) {
+	case "DEBUG":
+		return slog.LevelDebug
+	case "WARN":
+		return slog.LevelWarn
+	case "ERROR":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// isValidLogLevel reports whether level names one of validLogLevels.
+func isValidLogLevel(level string) bool {
+	for _, valid := range validLogLevels {
+		if strings.EqualFold(level, valid) {
+			return true
+		}
+	}
+	return false
+}
+
+// SetActive makes cfg the instance the Get* helpers read from.
+// Passing nil reverts the helpers to the built-in defaults.
+func SetActive(cfg *Config) {
+	activeMu.Lock()
+	defer activeMu.Unlock()
+	active = cfg
+}
+
+// current returns the active Config, or the defaults when none has been set.
+func current() *Config {
+	activeMu.RLock()
+	defer activeMu.RUnlock()
+	if active == nil {
+		return Default()
+	}
+	return active
+}
+
+// Load reads a configuration file and returns the resulting Config.
+// The format is chosen by extension: .json, or .yaml/.yml. Fields absent
+// from the file keep their built-in default values.
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	cfg := Default()
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json":
+		if err := json.Unmarshal(data, cfg); err != nil {
+			return nil, fmt.Errorf("failed to parse JSON config %s: %w", path, err)
+		}
+	case ".yaml", ".yml":
+		if err := parseYAML(data, cfg); err != nil {
+			return nil, fmt.Errorf("failed to parse YAML config %s: %w", path, err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported config file extension %q (want .json, .yaml or .yml)", ext)
+	}
+	return cfg, nil
+}
+
+// parseYAML applies a flat "key: value" YAML document onto cfg.
+// Only the scalar keys used by Config are understood; nested mappings and
+// sequences are rejected. Unknown keys are ignored, matching encoding/json.
+func parseYAML(data []byte, cfg *Config) error {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		raw := scanner.Text()
+		line := strings.TrimSpace(stripYAMLComment(raw))
+		if line == "" || line == "---" {
+			continue
+		}
+		if raw[0] == ' ' || raw[0] == '\t' || strings.HasPrefix(line, "- ") {
+			return fmt.Errorf("line %d: nested YAML is not supported", lineNo)
+		}
+
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			return fmt.Errorf("line %d: expected \"key: value\"", lineNo)
+		}
+		key = strings.TrimSpace(key)
+		value = unquoteYAML(strings.TrimSpace(value))
+
+		switch key {
+		case "data_path":
+			cfg.DataPath = value
+		case "threshold":
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("line %d: %w %q: %w", lineNo, ErrInvalidThreshold, value, err)
+			}
+			cfg.Threshold = n
+		case "log_level":
+			cfg.LogLevel = value
+		case "log_file":
+			cfg.LogFile = value
+		}
+	}
+	return scanner.Err()
+}
+
+// stripYAMLComment removes a trailing "# comment" that is not inside quotes.
+func stripYAMLComment(line string) string {
+	var quote rune
+	for i, r := range line {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '#' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t'):
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// unquoteYAML removes matching single or double quotes around a scalar.
+func unquoteYAML(value string) string {
+	if len(value) >= 2 {
+		first, last := value[0], value[len(value)-1]
+		if (first == '"' || first == '\'') && first == last {
+			return value[1 : len(value)-1]
+		}
+	}
+	return value
+}
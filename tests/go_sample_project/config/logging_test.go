@@ -0,0 +1,29 @@
+// tests/sample_project2/config/logging_test.go
+package config
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestSetLoggerCapturesOutput(t *testing.T) {
+	var buf bytes.Buffer
+	SetLogger(slog.New(slog.NewTextHandler(&buf, nil)))
+	t.Cleanup(func() { SetLogger(nil) })
+	t.Setenv(EnvThreshold, "lots")
+
+	cfg := Default()
+	applyEnv(cfg)
+
+	if cfg.Threshold != processingThreshold {
+		t.Errorf("Threshold = %d, want the default %d after an invalid override", cfg.Threshold, processingThreshold)
+	}
+	out := buf.String()
+	for _, want := range []string{"level=WARN", `msg="Config: ignoring invalid threshold override"`, "env=" + EnvThreshold, "value=lots"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("log output %q does not contain %q", out, want)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+// tests/sample_project2/config/resolve.go
+package config
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+)
+
+// Flag keys understood by Resolve. They match the command-line flag names.
+const (
+	FlagConfigFile = "config"
+	FlagDataPath   = "data"
+	FlagThreshold  = "threshold"
+	FlagLogLevel   = "log-level"
+	FlagLogFile    = "log-file"
+)
+
+// Resolve builds the effective configuration by layering, from lowest to
+// highest priority:
+//
+//  1. the built-in defaults,
+//  2. a config file, named by flags["config"] or else SOURCELENS_CONFIG (skipped if neither is set),
+//  3. the SOURCELENS_* environment variables,
+//  4. flags, keyed by FlagDataPath, FlagThreshold, FlagLogLevel and FlagLogFile.
+//
+// Only keys present in flags override lower layers, so callers should pass just
+// the flags the user actually set. Unknown keys and an unparsable threshold flag
+// are errors. The result is not validated and does not change the active Config.
+func Resolve(flags map[string]string) (*Config, error) {
+	cfg := Default()
+
+	path := flags[FlagConfigFile]
+	if path == "" {
+		path = os.Getenv(EnvConfigFile)
+	}
+	if path != "" {
+		loaded, err := Load(path)
+		if err != nil {
+			return nil, err
+		}
+		cfg = loaded
+	}
+
+	applyEnv(cfg)
+
+	keys := make([]string, 0, len(flags))
+	for key := range flags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		value := flags[key]
+		switch key {
+		case FlagConfigFile:
+			// Already handled above.
+		case FlagDataPath:
+			cfg.DataPath = value
+		case FlagThreshold:
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("%w flag %q: %w", ErrInvalidThreshold, value, err)
+			}
+			cfg.Threshold = n
+		case FlagLogLevel:
+			cfg.LogLevel = value
+		case FlagLogFile:
+			cfg.LogFile = value
+		default:
+			return nil, fmt.Errorf("unknown config flag %q", key)
+		}
+	}
+	return cfg, nil
+}
@@ -0,0 +1,80 @@
+// tests/sample_project2/config/resolve_test.go
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestResolvePrecedence(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(file, []byte(`{"data_path": "file.json", "threshold": 200, "log_level": "WARN"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		env   map[string]string
+		flags map[string]string
+		want  Config
+	}{
+		{
+			name: "defaults",
+			want: Config{DataPath: dataFilePath, Threshold: processingThreshold, LogLevel: logLevel},
+		},
+		{
+			name:  "file over defaults",
+			flags: map[string]string{FlagConfigFile: file},
+			want:  Config{DataPath: "file.json", Threshold: 200, LogLevel: "WARN"},
+		},
+		{
+			name: "file named by env",
+			env:  map[string]string{EnvConfigFile: file},
+			want: Config{DataPath: "file.json", Threshold: 200, LogLevel: "WARN"},
+		},
+		{
+			name:  "env over file",
+			env:   map[string]string{EnvThreshold: "300", EnvLogFile: "env.log"},
+			flags: map[string]string{FlagConfigFile: file},
+			want:  Config{DataPath: "file.json", Threshold: 300, LogLevel: "WARN", LogFile: "env.log"},
+		},
+		{
+			name:  "flags over env",
+			env:   map[string]string{EnvThreshold: "300", EnvDataPath: "env.json"},
+			flags: map[string]string{FlagConfigFile: file, FlagThreshold: "400"},
+			want:  Config{DataPath: "env.json", Threshold: 400, LogLevel: "WARN"},
+		},
+		{
+			name:  "flags over everything",
+			env:   map[string]string{EnvThreshold: "300", EnvDataPath: "env.json", EnvLogLevel: "ERROR"},
+			flags: map[string]string{FlagConfigFile: file, FlagThreshold: "400", FlagDataPath: "flag.json", FlagLogLevel: "DEBUG", FlagLogFile: "flag.log"},
+			want:  Config{DataPath: "flag.json", Threshold: 400, LogLevel: "DEBUG", LogFile: "flag.log"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range []string{EnvDataPath, EnvThreshold, EnvLogLevel, EnvLogFile, EnvConfigFile} {
+				t.Setenv(key, tt.env[key]) // empty values are ignored by Resolve
+			}
+			got, err := Resolve(tt.flags)
+			if err != nil {
+				t.Fatalf("Resolve: %v", err)
+			}
+			if *got != tt.want {
+				t.Errorf("Resolve = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveErrors(t *testing.T) {
+	t.Setenv(EnvConfigFile, "")
+	if _, err := Resolve(map[string]string{FlagThreshold: "lots"}); !errors.Is(err, ErrInvalidThreshold) {
+		t.Errorf("Resolve with a bad threshold error = %v, want ErrInvalidThreshold", err)
+	}
+	if _, err := Resolve(map[string]string{"colour": "blue"}); err == nil {
+		t.Error("Resolve accepted an unknown flag")
+	}
+}
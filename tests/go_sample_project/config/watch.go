@@ -0,0 +1,95 @@
+// tests/sample_project2/config/watch.go
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+)
+
+// DefaultWatchInterval is how often Watch polls when given a non-positive interval.
+const DefaultWatchInterval = 2 * time.Second
+
+// Watch polls the config file at path every interval and calls onChange with
+// the reloaded Config whenever its contents change. The file is loaded and
+// validated once up front; an error there is returned immediately and
+// onChange is not called for it. Later reloads that fail to load or validate
+// are skipped with a logged warning, keeping the last good Config, until the
+// file changes again. onChange runs on the watching goroutine and may call
+// SetActive to make the new settings take effect.
+// Watch blocks until ctx is done and then returns ctx.Err().
+func Watch(ctx context.Context, path string, interval time.Duration, onChange func(*Config)) error {
+	if interval <= 0 {
+		interval = DefaultWatchInterval
+	}
+	last, err := loadValid(path)
+	if err != nil {
+		return err
+	}
+	stamp, _ := statStamp(path)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+
+		current, err := statStamp(path)
+		if err != nil {
+			if current != stamp {
+				pkgLogger().Warn("Config: cannot stat watched file", "path", path, "error", err)
+			}
+			stamp = current
+			continue
+		}
+		if current == stamp {
+			continue
+		}
+		stamp = current
+
+		cfg, err := loadValid(path)
+		if err != nil {
+			pkgLogger().Warn("Config: skipping invalid reload", "path", path, "error", err)
+			continue
+		}
+		if *cfg == *last {
+			continue
+		}
+		pkgLogger().Info("Config: reloaded", "path", path)
+		last = cfg
+		onChange(cfg)
+	}
+}
+
+// loadValid loads the config file at path and validates it.
+func loadValid(path string) (*Config, error) {
+	cfg, err := Load(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// fileStamp identifies a version of a file for change detection.
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+	missing bool
+}
+
+// statStamp returns the current fileStamp of path. A missing or unreadable
+// file yields a stamp with missing set, together with the error.
+func statStamp(path string) (fileStamp, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileStamp{missing: true}, err
+	}
+	return fileStamp{modTime: info.ModTime(), size: info.Size()}, nil
+}
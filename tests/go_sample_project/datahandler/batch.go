@@ -0,0 +1,58 @@
+// tests/sample_project2/datahandler/batch.go
+package datahandler
+
+import (
+	"fmt"
+	"path/filepath"
+	"sourcelens/sampleproject2/models"
+)
+
+// SaveBatches writes items in chunks of the WithBatchSize size to files named
+// part-0001.json, part-0002.json, ... in the directory of the destination
+// path. The extension follows the handler's format, plus ".gz" when the
+// output is compressed. The final part may hold fewer items; no files are
+// written for an empty slice. Parts left over from an earlier, larger save
+// are not removed.
+// It returns the paths written so far, in order, even when err is non-nil.
+func (dh *DataHandler) SaveBatches(items []models.Item) ([]string, error) {
+	dh.saveMu.Lock()
+	defer dh.saveMu.Unlock()
+	paths, _, err := dh.saveBatches(items)
+	return paths, err
+}
+
+// saveBatches implements SaveBatches and also reports whether every part was
+// left unchanged; the caller must hold dh.saveMu.
+func (dh *DataHandler) saveBatches(items []models.Item) ([]string, bool, error) {
+	dest := dh.destination()
+	if dh.batchSize <= 0 {
+		return nil, false, fmt.Errorf("batched save requires a positive batch size, got %d", dh.batchSize)
+	}
+	if dh.isDir && dh.outputPath == "" {
+		return nil, false, fmt.Errorf("cannot save items to directory %s: set an output path for batched saves", dh.dataSourcePath)
+	}
+	if isURL(dest) {
+		return nil, false, fmt.Errorf("batched saves are only supported for files, not %s", dest)
+	}
+
+	ext := "." + dh.format.String()
+	if dh.compression.isGzip(dest) {
+		ext += ".gz"
+	}
+	dir := filepath.Dir(dest)
+	var paths []string
+	allSkipped := len(items) > 0
+	for start, part := 0, 1; start < len(items); start, part = start+dh.batchSize, part+1 {
+		end := min(start+dh.batchSize, len(items))
+		path := filepath.Join(dir, fmt.Sprintf("part-%04d%s", part, ext))
+		dh.logger.Debug("Saving batch", "path", path, "count", end-start)
+		skipped, err := dh.writeItems(path, items[start:end])
+		if err != nil {
+			return paths, false, fmt.Errorf("failed to save batch %d: %w", part, err)
+		}
+		allSkipped = allSkipped && skipped
+		paths = append(paths, path)
+	}
+	dh.logger.Info("Finished batched save", "count", len(items), "files", len(paths), "skipped_unchanged", allSkipped)
+	return paths, allSkipped, nil
+}
@@ -0,0 +1,77 @@
+// tests/sample_project2/datahandler/compression.go
+package datahandler
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Compression selects whether the data file is gzip-compressed.
+type Compression int
+
+const (
+	// CompressionAuto gzips when the path ends in ".gz" (the default).
+	CompressionAuto Compression = iota
+	// CompressionNone never compresses, regardless of the file name.
+	CompressionNone
+	// CompressionGzip always compresses, regardless of the file name.
+	CompressionGzip
+)
+
+// isGzip reports whether a file at path should be treated as gzip-compressed.
+func (c Compression) isGzip(path string) bool {
+	switch c {
+	case CompressionGzip:
+		return true
+	case CompressionNone:
+		return false
+	default:
+		return strings.HasSuffix(strings.ToLower(path), ".gz")
+	}
+}
+
+// gzipReader decompresses a gzip stream and labels any failure as a corrupt stream.
+type gzipReader struct {
+	zr *gzip.Reader
+}
+
+// newGzipReader reads the gzip header from r.
+func newGzipReader(r io.Reader) (*gzipReader, error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("corrupt gzip stream: %w", err)
+	}
+	return &gzipReader{zr: zr}, nil
+}
+
+// Read implements io.Reader.
+func (g *gzipReader) Read(p []byte) (int, error) {
+	n, err := g.zr.Read(p)
+	if err != nil && !errors.Is(err, io.EOF) {
+		err = fmt.Errorf("corrupt gzip stream: %w", err)
+	}
+	return n, err
+}
+
+// Close releases the decompressor; it does not close the underlying reader.
+func (g *gzipReader) Close() error {
+	return g.zr.Close()
+}
+
+// gzipEncoder wraps encode so that its output is gzip-compressed.
+func gzipEncoder(encode func(w io.Writer) error) func(w io.Writer) error {
+	return func(w io.Writer) error {
+		zw := gzip.NewWriter(w)
+		if err := encode(zw); err != nil {
+			zw.Close()
+			return err
+		}
+		if err := zw.Close(); err != nil {
+			return fmt.Errorf("failed to finish gzip stream: %w", err)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,158 @@
+// tests/sample_project2/datahandler/count.go
+package datahandler
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Count returns the number of items stored in the data source without
+// decoding them into Items: JSON array elements, CSV rows after the header,
+// non-blank NDJSON lines or <item> elements. For a directory handler the
+// files are summed. Items are not validated or deduplicated and WithLimit is
+// ignored, so Count can exceed len(LoadItems()).
+func (dh *DataHandler) Count() (int, error) {
+	ctx := context.Background()
+	paths := []string{dh.dataSourcePath}
+	if dh.isDir {
+		var err error
+		if paths, err = dh.dirFiles(); err != nil {
+			return 0, err
+		}
+	}
+
+	total := 0
+	for _, path := range paths {
+		n, err := dh.countFile(ctx, path)
+		if err != nil {
+			return 0, fmt.Errorf("failed to count items in %s: %w", path, err)
+		}
+		total += n
+	}
+	return total, nil
+}
+
+// countFile counts the items in a single file in the handler's format.
+func (dh *DataHandler) countFile(ctx context.Context, path string) (int, error) {
+	src, closeSrc, err := dh.open(ctx, path)
+	if err != nil {
+		return 0, err
+	}
+	defer closeSrc()
+
+	switch dh.format {
+	case FormatCSV:
+		return countCSV(src, dh.csvComma())
+	case FormatNDJSON:
+		return countNDJSON(src)
+	case FormatXML:
+		return countXML(src)
+	default:
+		return countJSON(src)
+	}
+}
+
+// countJSON counts the elements of a JSON array, holding one raw element in
+// memory at a time.
+func countJSON(r io.Reader) (int, error) {
+	dec := json.NewDecoder(r)
+	if err := expectDelim(dec, '['); err != nil {
+		return 0, err
+	}
+	n := 0
+	for ; dec.More(); n++ {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return 0, fmt.Errorf("item at index %d: %w", n, describeJSONError(err))
+		}
+	}
+	if err := expectDelim(dec, ']'); err != nil {
+		return 0, err
+	}
+	return n, expectEOF(dec)
+}
+
+// countCSV counts the records after the header row.
+func countCSV(r io.Reader, comma rune) (int, error) {
+	cr := newCSVReader(r, comma)
+	cr.ReuseRecord = true
+	n := -1 // The first record is the header.
+	for {
+		_, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return max(n, 0), nil
+		}
+		if err != nil {
+			return 0, fmt.Errorf("malformed CSV: %w", err)
+		}
+		n++
+	}
+}
+
+// countNDJSON counts the lines that are not blank. Lines of any length are
+// handled without holding more than one buffer of input.
+func countNDJSON(r io.Reader) (int, error) {
+	br := bufio.NewReader(r)
+	n := 0
+	blank := true
+	for {
+		chunk, err := br.ReadSlice('\n')
+		if len(bytes.TrimSpace(chunk)) > 0 {
+			blank = false
+		}
+		if errors.Is(err, bufio.ErrBufferFull) {
+			continue // The line continues in the next chunk.
+		}
+		if !blank {
+			n++
+		}
+		blank = true
+		if errors.Is(err, io.EOF) {
+			return n, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+}
+
+// countXML counts the <item> elements directly inside the <items> root.
+func countXML(r io.Reader) (int, error) {
+	dec := xml.NewDecoder(r)
+	root, err := nextStartElement(dec)
+	if errors.Is(err, io.EOF) {
+		return 0, fmt.Errorf("malformed XML: document has no <%s> element", xmlRootElement)
+	}
+	if err != nil {
+		return 0, describeXMLError(dec, "", err)
+	}
+	if root.Name.Local != xmlRootElement {
+		return 0, fmt.Errorf("malformed XML: expected root element <%s>, got <%s>", xmlRootElement, root.Name.Local)
+	}
+
+	n := 0
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return 0, describeXMLError(dec, xmlRootElement, err)
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if t.Name.Local != xmlItemElement {
+				return 0, fmt.Errorf("malformed XML at line %d: unexpected element <%s> in <%s>", lineOf(dec), t.Name.Local, xmlRootElement)
+			}
+			if err := dec.Skip(); err != nil {
+				return 0, describeXMLError(dec, xmlItemElement, err)
+			}
+			n++
+		case xml.EndElement:
+			return n, nil
+		}
+	}
+}
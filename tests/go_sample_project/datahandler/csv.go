@@ -0,0 +1,137 @@
+// tests/sample_project2/datahandler/csv.go
+package datahandler
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sourcelens/sampleproject2/models"
+	"strconv"
+	"strings"
+)
+
+// csvHeader is the column order written by encodeCSV.
+var csvHeader = []string{"ItemID", "Name", "Value", "Processed"}
+
+// utf8BOM is the byte order mark WithCSVBOM writes, which spreadsheet
+// programs use to detect UTF-8.
+const utf8BOM = "\uFEFF"
+
+// streamCSV reads CSV data separated by comma whose first row is a header
+// naming the columns, passing each parsed row to fn in order. A leading
+// UTF-8 byte order mark is ignored.
+// ItemID, Name and Value are required; Processed is optional and defaults to false.
+func streamCSV(src io.Reader, comma rune, fn func(models.Item) error) error {
+	r := newCSVReader(src, comma)
+	header, err := r.Read()
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("malformed CSV: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.TrimSpace(name)] = i
+	}
+	for _, required := range csvHeader[:3] {
+		if _, ok := columns[required]; !ok {
+			return fmt.Errorf("CSV header is missing required column %q", required)
+		}
+	}
+
+	for line := 2; ; line++ { // 1-based, counting the header row
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("malformed CSV: %w", err)
+		}
+		item, err := parseCSVRecord(record, columns)
+		if err != nil {
+			return fmt.Errorf("CSV line %d: %w", line, err)
+		}
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+}
+
+// parseCSVRecord converts a single CSV row into an Item using the header column positions.
+func parseCSVRecord(record []string, columns map[string]int) (models.Item, error) {
+	var item models.Item
+
+	id, err := strconv.Atoi(strings.TrimSpace(record[columns["ItemID"]]))
+	if err != nil {
+		return item, fmt.Errorf("invalid ItemID: %w", err)
+	}
+	value, err := strconv.ParseFloat(strings.TrimSpace(record[columns["Value"]]), 64)
+	if err != nil {
+		return item, fmt.Errorf("invalid Value: %w", err)
+	}
+	item = *models.NewItem(id, record[columns["Name"]], value)
+
+	if col, ok := columns["Processed"]; ok {
+		if raw := strings.TrimSpace(record[col]); raw != "" {
+			processed, err := strconv.ParseBool(raw)
+			if err != nil {
+				return item, fmt.Errorf("invalid Processed: %w", err)
+			}
+			item.Processed = processed
+		}
+	}
+	return item, nil
+}
+
+// newCSVReader returns a csv.Reader over src using comma as the field
+// separator, skipping a leading UTF-8 byte order mark.
+func newCSVReader(src io.Reader, comma rune) *csv.Reader {
+	br := bufio.NewReader(src)
+	if prefix, err := br.Peek(len(utf8BOM)); err == nil && string(prefix) == utf8BOM {
+		br.Discard(len(utf8BOM))
+	}
+	r := csv.NewReader(br)
+	r.Comma = comma
+	return r
+}
+
+// encodeCSV writes items to dst as CSV with a header row, separating fields
+// with comma and starting with a UTF-8 byte order mark when bom is set.
+// Names containing commas, quotes or newlines are quoted by encoding/csv.
+func encodeCSV(dst io.Writer, items []models.Item, comma rune, bom bool) error {
+	if bom {
+		if _, err := io.WriteString(dst, utf8BOM); err != nil {
+			return err
+		}
+	}
+	w := csv.NewWriter(dst)
+	w.Comma = comma
+	if err := w.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, item := range items {
+		record := []string{
+			strconv.Itoa(item.ItemID),
+			item.Name,
+			strconv.FormatFloat(item.Value, 'f', -1, 64),
+			strconv.FormatBool(item.Processed),
+		}
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// csvComma returns the CSV field separator set with WithCSVDelimiter, or ','.
+func (dh *DataHandler) csvComma() rune {
+	if dh.csvDelimiter == 0 {
+		return ','
+	}
+	return dh.csvDelimiter
+}
@@ -0,0 +1,81 @@
+// tests/sample_project2/datahandler/csv_test.go
+package datahandler
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sourcelens/sampleproject2/models"
+	"testing"
+)
+
+// csvItems includes a name with a comma, which needs no quoting in a
+// tab-separated file, and one with a tab, which does.
+func csvItems() []models.Item {
+	return []models.Item{
+		{ItemID: 1, Name: "Gadget, Alpha", Value: 150.75, Processed: true},
+		{ItemID: 2, Name: "Widget\tBeta", Value: 85},
+	}
+}
+
+func TestCSVRoundTrip(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []Option
+		wantFile string
+	}{
+		{
+			name:     "tab delimited",
+			opts:     []Option{WithCSVDelimiter('\t')},
+			wantFile: "ItemID\tName\tValue\tProcessed\n1\tGadget, Alpha\t150.75\ttrue\n2\t\"Widget\tBeta\"\t85\tfalse\n",
+		},
+		{
+			name:     "with BOM",
+			opts:     []Option{WithCSVBOM(true)},
+			wantFile: utf8BOM + "ItemID,Name,Value,Processed\n1,\"Gadget, Alpha\",150.75,true\n2,Widget\tBeta,85,false\n",
+		},
+		{
+			name:     "tab delimited with BOM",
+			opts:     []Option{WithCSVDelimiter('\t'), WithCSVBOM(true)},
+			wantFile: utf8BOM + "ItemID\tName\tValue\tProcessed\n1\tGadget, Alpha\t150.75\ttrue\n2\t\"Widget\tBeta\"\t85\tfalse\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "items.csv")
+			dh := NewCSVDataHandler(path, append([]Option{WithLogger(discardLogger)}, tt.opts...)...)
+			if _, err := dh.SaveItems(csvItems()); err != nil {
+				t.Fatal(err)
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != tt.wantFile {
+				t.Errorf("saved file = %q, want %q", data, tt.wantFile)
+			}
+
+			got, err := dh.LoadItems()
+			if err != nil {
+				t.Fatalf("LoadItems: %v", err)
+			}
+			if want := csvItems(); !reflect.DeepEqual(got, want) {
+				t.Errorf("round trip = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestCSVLoadIgnoresBOMWithoutOption(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "items.csv")
+	if err := os.WriteFile(path, []byte(utf8BOM+"ItemID,Name,Value\n1,Gadget Alpha,150.75\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	got, err := NewCSVDataHandler(path, WithLogger(discardLogger)).LoadItems()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].ItemID != 1 || got[0].Name != "Gadget Alpha" {
+		t.Errorf("LoadItems = %+v, want Gadget Alpha with ItemID 1", got)
+	}
+}
@@ -2,44 +2,515 @@
 package datahandler
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
 	"sourcelens/sampleproject2/models"
+	"sync"
+	"time"
 )
 
+// DataStore is the behavior required to load and persist Items.
+// The pipeline depends on this interface rather than on a concrete file format.
+type DataStore interface {
+	LoadItems() ([]models.Item, error)
+	// SaveItems persists items and reports how many were written.
+	SaveItems(items []models.Item) (int, error)
+}
+
+// ContextLoader is implemented by stores whose loads can be canceled.
+// Callers should type-assert for it and fall back to LoadItems.
+type ContextLoader interface {
+	LoadItemsCtx(ctx context.Context) ([]models.Item, error)
+}
+
+// UnvalidatedLoader is implemented by stores that can load items without
+// running models.Validate, so a preflight can report every invalid item
+// instead of failing on the first or dropping them in LenientMode.
+// Callers should type-assert for it.
+type UnvalidatedLoader interface {
+	LoadItemsUnvalidated(ctx context.Context) ([]models.Item, error)
+}
+
+// Format identifies the serialization used for the data file.
+type Format int
+
+const (
+	// FormatJSON stores items as a single JSON array.
+	FormatJSON Format = iota
+	// FormatCSV stores items as CSV with a header row of ItemID,Name,Value,Processed.
+	FormatCSV
+	// FormatNDJSON stores one JSON object per line (JSON Lines).
+	FormatNDJSON
+	// FormatXML stores items as <items><item>...</item></items>.
+	FormatXML
+)
+
+// String returns the lower-case name of the format.
+func (f Format) String() string {
+	switch f {
+	case FormatJSON:
+		return "json"
+	case FormatCSV:
+		return "csv"
+	case FormatNDJSON:
+		return "ndjson"
+	case FormatXML:
+		return "xml"
+	default:
+		return fmt.Sprintf("Format(%d)", int(f))
+	}
+}
+
 // DataHandler manages loading and saving Item data.
+// It is safe for concurrent use: saves are serialized, so concurrent SaveItems
+// calls each write a complete file and the last one wins. The exported policy
+// fields must not be changed while the handler is in use.
 type DataHandler struct {
+	// DeduplicationPolicy decides what LoadItems does with repeated ItemIDs.
+	DeduplicationPolicy DeduplicationPolicy
+	// RetryPolicy decides whether SaveItems retries transient write failures.
+	RetryPolicy RetryPolicy
+	// LenientMode makes LoadItems drop items that fail models.Validate instead of failing the load.
+	LenientMode bool
+
 	dataSourcePath string
+	outputPath     string
+	batchSize      int
+	fileMode       os.FileMode
+	skipUnchanged  bool
+	csvDelimiter   rune
+	csvBOM         bool
+	format         Format
+	compression    Compression
+	indent         string
+	limit          int
+	isDir          bool
+	httpClient     *http.Client
+	httpTimeout    time.Duration
+	logger         *slog.Logger
+
+	// saveMu serializes SaveItems and SaveBatches.
+	saveMu sync.Mutex
 }
 
+// Compile-time checks that DataHandler satisfies the store interfaces.
+var (
+	_ DataStore         = (*DataHandler)(nil)
+	_ ContextLoader     = (*DataHandler)(nil)
+	_ UnvalidatedLoader = (*DataHandler)(nil)
+)
+
 // NewDataHandler is a constructor for the DataHandler.
-func NewDataHandler(path string) *DataHandler {
-	log.Printf("DataHandler initialized for source: %s", path)
-	return &DataHandler{dataSourcePath: path}
+// By default it reads and writes compact JSON and logs to slog.Default();
+// use the With* options to change this.
+func NewDataHandler(path string, opts ...Option) *DataHandler {
+	dh := &DataHandler{dataSourcePath: path, format: FormatJSON, logger: slog.Default()}
+	for _, opt := range opts {
+		opt(dh)
+	}
+	dh.logger.Info("DataHandler initialized", "source", path, "format", dh.format)
+	return dh
 }
 
-// LoadItems simulates loading items from the data source.
+// NewCSVDataHandler is a constructor for a DataHandler that reads and writes CSV.
+// It is shorthand for NewDataHandler(path, WithFormat(FormatCSV), opts...).
+func NewCSVDataHandler(path string, opts ...Option) *DataHandler {
+	return NewDataHandler(path, append([]Option{WithFormat(FormatCSV)}, opts...)...)
+}
+
+// LoadItems reads the data file at the data source path, transparently
+// decompressing it when the path ends in ".gz" or WithCompression forces it.
+// For JSON the file must contain an array of objects with ItemID, Name and Value fields.
+// A handler created with NewDirDataHandler reads every file of its format in the directory instead,
+// and an http:// or https:// path is fetched with GET; a non-2xx response is a *StatusError.
+// When WithLimit is set only the first n items are decoded.
 // It returns a slice of Items and an error (idiomatic Go).
 func (dh *DataHandler) LoadItems() ([]models.Item, error) {
-	log.Printf("Simulating loading items from %s...", dh.dataSourcePath)
-	
-	items := []models.Item{
-		*models.NewItem(1, "Gadget Alpha", 150.75),
-		*models.NewItem(2, "Widget Beta", 85.0),
-		*models.NewItem(3, "Thingamajig Gamma", 210.5),
-		*models.NewItem(4, "Doohickey Delta", 55.2),
+	return dh.LoadItemsCtx(context.Background())
+}
+
+// LoadItemsCtx is LoadItems with cancellation: an HTTP fetch is aborted and a
+// file read stops between chunks once ctx is done, returning an error that
+// wraps ctx.Err().
+func (dh *DataHandler) LoadItemsCtx(ctx context.Context) ([]models.Item, error) {
+	items, err := dh.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if items, err = deduplicate(items, dh.DeduplicationPolicy); err != nil {
+		return nil, &LoadError{Path: dh.dataSourcePath, Err: err}
 	}
 
-	log.Printf("Loaded %d items.", len(items))
+	dh.logger.Info("Loaded items", "count", len(items))
 	return items, nil // Return nil for the error to indicate success
 }
 
-// SaveItems simulates saving processed items.
-func (dh *DataHandler) SaveItems(items []models.Item) (bool, error) {
-	log.Printf("Simulating saving %d items to %s...", len(items), dh.dataSourcePath)
+// LoadItemsUnvalidated is LoadItemsCtx without models.Validate: invalid items
+// are returned as decoded, whatever LenientMode says. WithLimit and the
+// DeduplicationPolicy still apply.
+func (dh *DataHandler) LoadItemsUnvalidated(ctx context.Context) ([]models.Item, error) {
+	items, err := dh.read(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	if items, err = deduplicate(items, dh.DeduplicationPolicy); err != nil {
+		return nil, &LoadError{Path: dh.dataSourcePath, Err: err}
+	}
+	return items, nil
+}
+
+// LoadItemsByID is like LoadItems but returns the items keyed by ItemID.
+// Any repeated ItemID is an error, whatever the DeduplicationPolicy.
+func (dh *DataHandler) LoadItemsByID() (map[int]models.Item, error) {
+	items, err := dh.load(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	if _, err := deduplicate(items, DedupError); err != nil {
+		return nil, &LoadError{Path: dh.dataSourcePath, Err: err}
+	}
+	byID := make(map[int]models.Item, len(items))
 	for _, item := range items {
-		log.Printf("Saving item: %s", item.String())
+		byID[item.ItemID] = item
+	}
+	dh.logger.Info("Loaded items", "count", len(byID))
+	return byID, nil
+}
+
+// load reads and validates the items from the data source, a directory or a
+// single file, honoring WithLimit but not the DeduplicationPolicy.
+func (dh *DataHandler) load(ctx context.Context) ([]models.Item, error) {
+	return dh.read(ctx, true)
+}
+
+// read is load with validation optional.
+func (dh *DataHandler) read(ctx context.Context, validate bool) ([]models.Item, error) {
+	dh.logger.Info("Loading items", "source", dh.dataSourcePath, "format", dh.format)
+	if dh.isDir {
+		return dh.loadDir(ctx, dh.limit, validate)
+	}
+	return dh.loadFile(ctx, dh.dataSourcePath, 0, dh.limit, validate)
+}
+
+// open returns a reader over the decompressed contents of the file or URL at
+// path that fails once ctx is done, and a function that releases it.
+func (dh *DataHandler) open(ctx context.Context, path string) (io.Reader, func(), error) {
+	var f io.ReadCloser
+	var err error
+	if isURL(path) {
+		f, err = dh.fetchURL(ctx, path)
+	} else {
+		f, err = os.Open(path)
+		if err != nil {
+			err = fmt.Errorf("failed to open data file: %w", err)
+		}
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var src io.Reader = ctxReader{ctx: ctx, r: f}
+	if !dh.compression.isGzip(path) {
+		return src, func() { f.Close() }, nil
+	}
+	zr, err := newGzipReader(src)
+	if err != nil {
+		f.Close()
+		return nil, nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return zr, func() { zr.Close(); f.Close() }, nil
+}
+
+// loadFile decodes the items in a single file, validating them when validate
+// is set, skipping the first offset items and reading at most limit items
+// when limit is positive. Errors are *LoadError values naming the file.
+func (dh *DataHandler) loadFile(ctx context.Context, path string, offset, limit int, validate bool) ([]models.Item, error) {
+	src, closeSrc, err := dh.open(ctx, path)
+	if err != nil {
+		return nil, &LoadError{Path: path, Err: err}
+	}
+	defer closeSrc()
+
+	items, err := dh.readItems(ctx, src, offset, limit, validate)
+	if err != nil {
+		return nil, &LoadError{Path: path, Err: err}
+	}
+	return items, nil
+}
+
+// readItems decodes items from src like decode and, when validate is set,
+// checks them with validateItems. loadFile and LoadItemsFrom share it so the
+// two cannot drift apart.
+func (dh *DataHandler) readItems(ctx context.Context, src io.Reader, offset, limit int, validate bool) ([]models.Item, error) {
+	items, err := dh.decode(ctx, src, offset, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode items: %w", err)
+	}
+	if !validate {
+		return items, nil
+	}
+	if items, err = dh.validateItems(items); err != nil {
+		return nil, fmt.Errorf("invalid items: %w", err)
+	}
+	return items, nil
+}
+
+// errLimitReached stops a stream once the handler's item limit is hit.
+var errLimitReached = errors.New("item limit reached")
+
+// decode reads items from src in the handler's format, discarding the first
+// offset and keeping up to limit of the rest; limit <= 0 keeps everything.
+// Decoding is streamed, so skipped items are not retained and input past the
+// limit is never parsed.
+func (dh *DataHandler) decode(ctx context.Context, src io.Reader, offset, limit int) ([]models.Item, error) {
+	var stream func(io.Reader, func(models.Item) error) error
+	switch dh.format {
+	case FormatCSV:
+		stream = func(r io.Reader, fn func(models.Item) error) error {
+			return streamCSV(r, dh.csvComma(), fn)
+		}
+	case FormatNDJSON:
+		stream = streamNDJSON
+	case FormatXML:
+		stream = streamXML
+	default:
+		stream = streamJSON
+	}
+
+	items := []models.Item{}
+	seen := 0
+	err := stream(src, func(item models.Item) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if seen++; seen <= offset {
+			return nil
+		}
+		items = append(items, item)
+		if limit > 0 && len(items) >= limit {
+			return errLimitReached
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errLimitReached) {
+		return nil, err
 	}
-	log.Println("Finished simulating save operation.")
-	return true, nil
-}
\ No newline at end of file
+	return items, nil
+}
+
+// ctxReader fails reads once ctx is done, so a streaming decoder stops at
+// the next chunk after cancellation.
+type ctxReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (cr ctxReader) Read(p []byte) (int, error) {
+	if err := cr.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return cr.r.Read(p)
+}
+
+// validateItems runs models.Validate on every item. Failures are collected with
+// their index; in LenientMode they are logged and the invalid items dropped,
+// otherwise they are returned together as one error.
+func (dh *DataHandler) validateItems(items []models.Item) ([]models.Item, error) {
+	valid := items[:0:0]
+	var errs []error
+	for i, item := range items {
+		if err := models.Validate(item); err != nil {
+			errs = append(errs, fmt.Errorf("item at index %d: %w", i, err))
+			continue
+		}
+		valid = append(valid, item)
+	}
+	if len(errs) == 0 {
+		return items, nil
+	}
+	if !dh.LenientMode {
+		return nil, errors.Join(errs...)
+	}
+	dh.logger.Warn("Skipping invalid items", "skipped", len(errs), "error", errors.Join(errs...))
+	return valid, nil
+}
+
+// LoadItemsFrom decodes a JSON array of items from r and validates them the
+// way LoadItems does without LenientMode: anything but an array, including
+// null, and any invalid item is an error. Anything other than whitespace
+// after the array is reported as an error too.
+func LoadItemsFrom(r io.Reader) ([]models.Item, error) {
+	dh := &DataHandler{format: FormatJSON, logger: slog.Default()}
+	return dh.readItems(context.Background(), r, 0, 0, true)
+}
+
+// describeJSONError adds the byte offset, where available, to a decoding error.
+func describeJSONError(err error) error {
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		return fmt.Errorf("malformed JSON at byte offset %d: %w", syntaxErr.Offset, err)
+	}
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return fmt.Errorf("invalid value for field %q at byte offset %d: %w", typeErr.Field, typeErr.Offset, err)
+	}
+	if errors.Is(err, io.EOF) {
+		return errors.New("empty JSON input: expected an array of items")
+	}
+	return err
+}
+
+// SaveItems writes the items to the destination (see WithOutputPath) in the handler's format,
+// gzip-compressed when the path ends in ".gz" or WithCompression forces it.
+// The data is written to a temporary file in the same directory and then renamed
+// over the destination, so readers never observe a partially written file.
+// For an http:// or https:// path the encoded items are POSTed instead.
+// Retryable write failures are retried according to dh.RetryPolicy.
+// With WithBatchSize set it behaves like SaveBatches.
+// It returns the number of items written, which is 0 whenever err is non-nil.
+func (dh *DataHandler) SaveItems(items []models.Item) (int, error) {
+	n, _, err := dh.save(items)
+	return n, err
+}
+
+// save implements SaveItems and SaveItemsIfChanged.
+func (dh *DataHandler) save(items []models.Item) (int, bool, error) {
+	dest := dh.destination()
+	dh.logger.Info("Saving items", "count", len(items), "destination", dest, "format", dh.format)
+	if dh.isDir && dh.outputPath == "" {
+		return 0, false, fmt.Errorf("cannot save items to directory %s: saving is only supported for single files", dh.dataSourcePath)
+	}
+
+	dh.saveMu.Lock()
+	defer dh.saveMu.Unlock()
+	if dh.batchSize > 0 {
+		_, skipped, err := dh.saveBatches(items)
+		if err != nil {
+			return 0, false, err
+		}
+		return len(items), skipped, nil
+	}
+	skipped, err := dh.writeItems(dest, items)
+	if err != nil {
+		return 0, false, err
+	}
+
+	dh.logger.Info("Finished save operation", "count", len(items), "skipped_unchanged", skipped)
+	return len(items), skipped, nil
+}
+
+// writeItems encodes items in the handler's format and writes them to dest,
+// retrying according to dh.RetryPolicy. It reports whether the write was
+// skipped because of WithSkipUnchangedSave.
+func (dh *DataHandler) writeItems(dest string, items []models.Item) (bool, error) {
+	var encode func(w io.Writer) error
+	switch dh.format {
+	case FormatCSV:
+		encode = func(w io.Writer) error { return encodeCSV(w, items, dh.csvComma(), dh.csvBOM) }
+	case FormatNDJSON:
+		encode = func(w io.Writer) error { return encodeNDJSON(w, items) }
+	case FormatXML:
+		encode = func(w io.Writer) error { return encodeXML(w, items, dh.indent) }
+	default:
+		encode = func(w io.Writer) error { return encodeJSON(w, items, dh.indent) }
+	}
+	if dh.compression.isGzip(dest) {
+		encode = gzipEncoder(encode)
+	}
+	fileMode, dirMode := dh.modes()
+	if dh.skipUnchanged && !isURL(dest) && unchanged(dest, encode) {
+		dh.logger.Info("Skipping save, contents unchanged", "destination", dest)
+		return true, nil
+	}
+	write := func() error { return writeFileAtomic(dest, fileMode, dirMode, encode) }
+	if isURL(dest) {
+		write = func() error { return dh.postURL(dest, encode) }
+	}
+	return false, dh.RetryPolicy.do(write, func(attempt int, wait time.Duration, err error) {
+		dh.logger.Warn("Save attempt failed, retrying", "attempt", attempt, "backoff", wait, "error", err)
+	})
+}
+
+// destination returns the path SaveItems writes to: the output path when one
+// was set with WithOutputPath, otherwise the data source itself.
+func (dh *DataHandler) destination() string {
+	if dh.outputPath != "" {
+		return dh.outputPath
+	}
+	return dh.dataSourcePath
+}
+
+// SaveProcessedItems is like SaveItems but persists only items with Processed set.
+// If none are processed an empty collection is written; the write is atomic either way.
+func (dh *DataHandler) SaveProcessedItems(items []models.Item) (int, error) {
+	return dh.SaveItems(models.FilterProcessed(items))
+}
+
+// SaveItemsTo encodes items to w as a JSON array followed by a newline.
+// A nil slice is written as an empty array.
+func SaveItemsTo(w io.Writer, items []models.Item) error {
+	return encodeJSON(w, items, "")
+}
+
+// encodeJSON writes items to w as a JSON array followed by a newline,
+// with each level indented by indent, or compact when indent is empty.
+// The output matches json.MarshalIndent.
+func encodeJSON(w io.Writer, items []models.Item, indent string) error {
+	if items == nil {
+		items = []models.Item{}
+	}
+	enc := json.NewEncoder(w)
+	if indent != "" {
+		enc.SetIndent("", indent)
+	}
+	if err := enc.Encode(items); err != nil {
+		return fmt.Errorf("failed to encode items: %w", err)
+	}
+	return nil
+}
+
+// writeFileAtomic streams the output of write into a temporary file next to path
+// and renames it into place with permissions fileMode. Missing parent
+// directories are created with dirMode.
+func writeFileAtomic(path string, fileMode, dirMode os.FileMode, write func(w io.Writer) error) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, dirMode); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	// Clean up the temporary file on any failure; after a successful rename this is a no-op.
+	defer os.Remove(tmpPath)
+
+	if err := write(tmp); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write %s: %w", tmpPath, err)
+	}
+	// os.CreateTemp uses 0600; set the requested mode explicitly so the umask does not apply.
+	if err := tmp.Chmod(fileMode); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to set permissions on %s: %w", tmpPath, err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to sync %s: %w", tmpPath, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close %s: %w", tmpPath, err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("failed to move data file into place: %w", err)
+	}
+	return nil
+}
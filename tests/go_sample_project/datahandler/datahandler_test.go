@@ -0,0 +1,138 @@
+// tests/sample_project2/datahandler/datahandler_test.go
+package datahandler
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sourcelens/sampleproject2/models"
+	"strings"
+	"sync"
+	"testing"
+)
+
+var discardLogger = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+func TestSaveItemsConcurrent(t *testing.T) {
+	const writers, perWriter = 20, 50
+	dh := NewDataHandler(filepath.Join(t.TempDir(), "items.json"), WithLogger(discardLogger))
+
+	var wg sync.WaitGroup
+	for w := 0; w < writers; w++ {
+		// Each writer saves its own set, tagged by Name, so a torn or
+		// interleaved write shows up as a mix of names.
+		items := make([]models.Item, perWriter)
+		for i := range items {
+			items[i] = models.Item{ItemID: i + 1, Name: "writer-" + string(rune('a'+w)), Value: float64(w)}
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := dh.SaveItems(items); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	got, err := dh.LoadItems()
+	if err != nil {
+		t.Fatalf("file is not valid after concurrent saves: %v", err)
+	}
+	if len(got) != perWriter {
+		t.Fatalf("loaded %d items, want %d", len(got), perWriter)
+	}
+	for _, item := range got {
+		if item.Name != got[0].Name {
+			t.Fatalf("file mixes items from %q and %q", got[0].Name, item.Name)
+		}
+	}
+}
+
+func TestLoadItemsWithLimit(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "items.json")
+	items := []models.Item{
+		{ItemID: 1, Name: "Gadget Alpha", Value: 150.75},
+		{ItemID: 2, Name: "Widget Beta", Value: 85.0},
+		{ItemID: 3, Name: "Thingamajig Gamma", Value: 210.5},
+	}
+	if _, err := NewDataHandler(path, WithLogger(discardLogger)).SaveItems(items); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct{ limit, want int }{{0, 3}, {-1, 3}, {2, 2}, {5, 3}} {
+		got, err := NewDataHandler(path, WithLogger(discardLogger), WithLimit(tt.limit)).LoadItems()
+		if err != nil {
+			t.Fatalf("limit %d: %v", tt.limit, err)
+		}
+		if len(got) != tt.want {
+			t.Errorf("limit %d: loaded %d items, want %d", tt.limit, len(got), tt.want)
+		}
+		for i := range got {
+			if got[i].ItemID != items[i].ItemID {
+				t.Errorf("limit %d: item %d has ItemID %d, want %d", tt.limit, i, got[i].ItemID, items[i].ItemID)
+			}
+		}
+	}
+}
+
+func TestSaveItemsIfChanged(t *testing.T) {
+	items := []models.Item{{ItemID: 1, Name: "Gadget Alpha", Value: 150.75}}
+	changed := []models.Item{{ItemID: 1, Name: "Gadget Alpha", Value: 99}}
+	tests := []struct {
+		name string
+		opts []Option
+		// want is whether each of the three saves (items, items, changed) is skipped.
+		want [3]bool
+	}{
+		{"skip unchanged", []Option{WithSkipUnchangedSave()}, [3]bool{false, true, false}},
+		{"batched", []Option{WithSkipUnchangedSave(), WithBatchSize(1)}, [3]bool{false, true, false}},
+		{"always write", nil, [3]bool{false, false, false}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dh := NewDataHandler(filepath.Join(t.TempDir(), "items.json"), append([]Option{WithLogger(discardLogger)}, tt.opts...)...)
+			for i, batch := range [][]models.Item{items, items, changed} {
+				n, skipped, err := dh.SaveItemsIfChanged(batch)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if n != len(batch) || skipped != tt.want[i] {
+					t.Errorf("save %d = (%d, %v), want (%d, %v)", i+1, n, skipped, len(batch), tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestLoadItemsFromMatchesLoadItems(t *testing.T) {
+	tests := []struct {
+		name, input string
+		wantErr     bool
+	}{
+		{"valid", `[{"ItemID": 1, "Name": "Gadget Alpha", "Value": 150.75}]`, false},
+		{"empty array", `[]`, false},
+		{"null", `null`, true},
+		{"object", `{"ItemID": 1, "Name": "Gadget Alpha", "Value": 150.75}`, true},
+		{"invalid item", `[{"ItemID": 1, "Name": "", "Value": 150.75}]`, true},
+		{"trailing data", `[] []`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "items.json")
+			if err := os.WriteFile(path, []byte(tt.input), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			fromFile, fileErr := NewDataHandler(path, WithLogger(discardLogger)).LoadItems()
+			fromReader, readerErr := LoadItemsFrom(strings.NewReader(tt.input))
+			if (fileErr != nil) != tt.wantErr || (readerErr != nil) != tt.wantErr {
+				t.Fatalf("LoadItems error = %v, LoadItemsFrom error = %v, want error %v", fileErr, readerErr, tt.wantErr)
+			}
+			if !reflect.DeepEqual(fromReader, fromFile) {
+				t.Errorf("LoadItemsFrom = %+v, LoadItems = %+v", fromReader, fromFile)
+			}
+		})
+	}
+}
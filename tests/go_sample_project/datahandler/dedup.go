@@ -0,0 +1,43 @@
+// tests/sample_project2/datahandler/dedup.go
+package datahandler
+
+import (
+	"fmt"
+	"sourcelens/sampleproject2/models"
+)
+
+// DeduplicationPolicy controls how LoadItems treats items that share an ItemID.
+type DeduplicationPolicy int
+
+const (
+	// DedupNone returns items exactly as they appear in the source (the default).
+	DedupNone DeduplicationPolicy = iota
+	// DedupKeepLast keeps one item per ItemID: the last occurrence in the source wins,
+	// placed at the position where that ItemID first appeared.
+	DedupKeepLast
+	// DedupError makes LoadItems fail when any ItemID appears more than once.
+	DedupError
+)
+
+// deduplicate applies policy to items, returning the resulting slice.
+func deduplicate(items []models.Item, policy DeduplicationPolicy) ([]models.Item, error) {
+	if policy == DedupNone {
+		return items, nil
+	}
+
+	firstIndex := make(map[int]int, len(items))
+	result := make([]models.Item, 0, len(items))
+	for i, item := range items {
+		pos, seen := firstIndex[item.ItemID]
+		if !seen {
+			firstIndex[item.ItemID] = len(result)
+			result = append(result, item)
+			continue
+		}
+		if policy == DedupError {
+			return nil, fmt.Errorf("duplicate ItemID %d at index %d (first seen at index %d)", item.ItemID, i, pos)
+		}
+		result[pos] = item
+	}
+	return result, nil
+}
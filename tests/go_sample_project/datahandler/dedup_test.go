@@ -0,0 +1,97 @@
+// tests/sample_project2/datahandler/dedup_test.go
+package datahandler
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"sourcelens/sampleproject2/models"
+	"testing"
+)
+
+// duplicateItems has three items sharing ItemID 2, interleaved with others.
+func duplicateItems() []models.Item {
+	return []models.Item{
+		{ItemID: 1, Name: "Gadget Alpha", Value: 150.75},
+		{ItemID: 2, Name: "Widget Beta v1", Value: 85.0},
+		{ItemID: 3, Name: "Thingamajig Gamma", Value: 210.5},
+		{ItemID: 2, Name: "Widget Beta v2", Value: 90.0},
+		{ItemID: 4, Name: "Doohickey Delta", Value: 55.2},
+		{ItemID: 2, Name: "Widget Beta v3", Value: 95.0},
+	}
+}
+
+func TestDeduplicateNone(t *testing.T) {
+	got, err := deduplicate(duplicateItems(), DedupNone)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := duplicateItems(); !reflect.DeepEqual(got, want) {
+		t.Errorf("DedupNone = %v, want input unchanged %v", got, want)
+	}
+}
+
+func TestDeduplicateKeepLast(t *testing.T) {
+	got, err := deduplicate(duplicateItems(), DedupKeepLast)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The last ItemID 2 wins, at the position where ItemID 2 first appeared.
+	want := []models.Item{
+		{ItemID: 1, Name: "Gadget Alpha", Value: 150.75},
+		{ItemID: 2, Name: "Widget Beta v3", Value: 95.0},
+		{ItemID: 3, Name: "Thingamajig Gamma", Value: 210.5},
+		{ItemID: 4, Name: "Doohickey Delta", Value: 55.2},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DedupKeepLast = %v, want %v", got, want)
+	}
+}
+
+func TestDeduplicateError(t *testing.T) {
+	got, err := deduplicate(duplicateItems(), DedupError)
+	if err == nil {
+		t.Fatalf("DedupError returned %v, want an error", got)
+	}
+	if want := "duplicate ItemID 2 at index 3 (first seen at index 1)"; err.Error() != want {
+		t.Errorf("DedupError error = %q, want %q", err, want)
+	}
+}
+
+func TestLoadItemsDeduplicationPolicy(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "items.json")
+	if _, err := NewDataHandler(path, WithLogger(discardLogger)).SaveItems(duplicateItems()); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		policy  DeduplicationPolicy
+		wantIDs []int
+		wantErr bool
+	}{
+		{DedupNone, []int{1, 2, 3, 2, 4, 2}, false},
+		{DedupKeepLast, []int{1, 2, 3, 4}, false},
+		{DedupError, nil, true},
+	}
+	for _, tt := range tests {
+		dh := NewDataHandler(path, WithLogger(discardLogger))
+		dh.DeduplicationPolicy = tt.policy
+		items, err := dh.LoadItems()
+		if tt.wantErr {
+			var loadErr *LoadError
+			if !errors.As(err, &loadErr) || loadErr.Path != path {
+				t.Errorf("policy %d: LoadItems error = %v, want a *LoadError for %s", tt.policy, err, path)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("policy %d: %v", tt.policy, err)
+		}
+		var ids []int
+		for _, item := range items {
+			ids = append(ids, item.ItemID)
+		}
+		if !reflect.DeepEqual(ids, tt.wantIDs) {
+			t.Errorf("policy %d: loaded ItemIDs %v, want %v", tt.policy, ids, tt.wantIDs)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+// tests/sample_project2/datahandler/dir.go
+package datahandler
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sourcelens/sampleproject2/models"
+	"strings"
+)
+
+// NewDirDataHandler is a constructor for a DataHandler whose items are sharded
+// across the files in dir that match its format: *.json by default, *.csv for
+// FormatCSV, *.ndjson or *.jsonl for FormatNDJSON and *.xml for FormatXML,
+// each optionally gzipped with a ".gz" suffix. LoadItems reads the files in
+// name order and concatenates their items; SaveItems is not supported.
+func NewDirDataHandler(dir string, opts ...Option) *DataHandler {
+	dh := NewDataHandler(dir, opts...)
+	dh.isDir = true
+	return dh
+}
+
+// loadDir loads every shard in the handler's directory in sorted order,
+// validating the items when validate is set. A positive limit caps the
+// combined total, so later files may not be read.
+func (dh *DataHandler) loadDir(ctx context.Context, limit int, validate bool) ([]models.Item, error) {
+	paths, err := dh.dirFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	items := []models.Item{}
+	for _, path := range paths {
+		remaining := 0
+		if limit > 0 {
+			remaining = limit - len(items)
+			if remaining <= 0 {
+				break
+			}
+		}
+		fileItems, err := dh.loadFile(ctx, path, 0, remaining, validate)
+		if err != nil {
+			return nil, err
+		}
+		dh.logger.Debug("Loaded shard", "file", path, "count", len(fileItems))
+		items = append(items, fileItems...)
+	}
+	return items, nil
+}
+
+// dirFiles lists the regular files in the handler's directory that match its
+// format, sorted by name.
+func (dh *DataHandler) dirFiles() ([]string, error) {
+	entries, err := os.ReadDir(dh.dataSourcePath)
+	if err != nil {
+		return nil, &LoadError{Path: dh.dataSourcePath, Err: fmt.Errorf("failed to read data directory: %w", err)}
+	}
+	var paths []string
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() || !dh.isShard(entry.Name()) {
+			continue
+		}
+		paths = append(paths, filepath.Join(dh.dataSourcePath, entry.Name()))
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// isShard reports whether a file named name holds items in the handler's format.
+func (dh *DataHandler) isShard(name string) bool {
+	ext := filepath.Ext(strings.TrimSuffix(strings.ToLower(name), ".gz"))
+	switch dh.format {
+	case FormatCSV:
+		return ext == ".csv"
+	case FormatNDJSON:
+		return ext == ".ndjson" || ext == ".jsonl"
+	case FormatXML:
+		return ext == ".xml"
+	default:
+		return ext == ".json"
+	}
+}
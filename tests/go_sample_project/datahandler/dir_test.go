@@ -0,0 +1,73 @@
+// tests/sample_project2/datahandler/dir_test.go
+package datahandler
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// writeShards creates a directory holding two shards in each format plus an unrelated file.
+func writeShards(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	shards := map[string]string{
+		"b.json":    `[{"ItemID": 2, "Name": "Widget Beta", "Value": 85}]`,
+		"a.json":    `[{"ItemID": 1, "Name": "Gadget Alpha", "Value": 150.75}]`,
+		"b.csv":     "ItemID,Name,Value\n12,Widget Beta,85\n",
+		"a.csv":     "ItemID,Name,Value\n11,Gadget Alpha,150.75\n",
+		"a.ndjson":  `{"ItemID": 21, "Name": "Gadget Alpha", "Value": 150.75}` + "\n",
+		"b.jsonl":   `{"ItemID": 22, "Name": "Widget Beta", "Value": 85}` + "\n",
+		"notes.txt": "not a shard",
+	}
+	for name, content := range shards {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestDirDataHandlerHonorsFormat(t *testing.T) {
+	dir := writeShards(t)
+	tests := []struct {
+		name    string
+		opts    []Option
+		wantIDs []int
+	}{
+		{"json by default", nil, []int{1, 2}},
+		{"csv", []Option{WithFormat(FormatCSV)}, []int{11, 12}},
+		{"ndjson", []Option{WithFormat(FormatNDJSON)}, []int{21, 22}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dh := NewDirDataHandler(dir, append([]Option{WithLogger(discardLogger)}, tt.opts...)...)
+			items, err := dh.LoadItems()
+			if err != nil {
+				t.Fatal(err)
+			}
+			var ids []int
+			for _, item := range items {
+				ids = append(ids, item.ItemID)
+			}
+			if !reflect.DeepEqual(ids, tt.wantIDs) {
+				t.Errorf("loaded ItemIDs %v, want %v", ids, tt.wantIDs)
+			}
+		})
+	}
+}
+
+func TestDirDataHandlerNamesFailingShard(t *testing.T) {
+	dir := writeShards(t)
+	bad := filepath.Join(dir, "c.csv")
+	if err := os.WriteFile(bad, []byte("ItemID,Name,Value\nnot-a-number,Broken,1\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	_, err := NewDirDataHandler(dir, WithLogger(discardLogger), WithFormat(FormatCSV)).LoadItems()
+	var loadErr *LoadError
+	if !errors.As(err, &loadErr) || loadErr.Path != bad {
+		t.Errorf("LoadItems error = %v, want a *LoadError for %s", err, bad)
+	}
+}
@@ -0,0 +1,23 @@
+// tests/sample_project2/datahandler/errors.go
+package datahandler
+
+import "fmt"
+
+// LoadError reports a failure to read items from the file, directory or URL
+// at Path. LoadItems and LoadItemsPage return it for open, decode, validation
+// and deduplication problems; use errors.As to get the path and errors.Is to
+// test the cause, e.g. fs.ErrNotExist or a *StatusError.
+type LoadError struct {
+	Path string
+	Err  error
+}
+
+// Error implements the error interface.
+func (e *LoadError) Error() string {
+	return fmt.Sprintf("failed to load items from %s: %v", e.Path, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *LoadError) Unwrap() error {
+	return e.Err
+}
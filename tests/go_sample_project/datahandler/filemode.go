@@ -0,0 +1,22 @@
+// tests/sample_project2/datahandler/filemode.go
+package datahandler
+
+import "os"
+
+// Permissions SaveItems uses for data files and the directories it creates
+// unless WithFileMode says otherwise. Directories are still subject to the umask.
+const (
+	DefaultFileMode os.FileMode = 0o644
+	DefaultDirMode  os.FileMode = 0o755
+)
+
+// modes returns the permissions for saved files and created directories.
+// A directory gets the file mode plus execute wherever read is granted, so
+// 0600 files live in 0700 directories.
+func (dh *DataHandler) modes() (file, dir os.FileMode) {
+	if dh.fileMode == 0 {
+		return DefaultFileMode, DefaultDirMode
+	}
+	file = dh.fileMode.Perm()
+	return file, file | (file&0o444)>>2
+}
@@ -0,0 +1,54 @@
+// tests/sample_project2/datahandler/filemode_test.go
+package datahandler
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"sourcelens/sampleproject2/models"
+	"testing"
+)
+
+func TestWithFileMode(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Unix permission bits are not supported on Windows")
+	}
+	tests := []struct {
+		name     string
+		opts     []Option
+		wantFile os.FileMode
+		wantDir  os.FileMode
+	}{
+		{"defaults", nil, DefaultFileMode, DefaultDirMode},
+		{"zero restores defaults", []Option{WithFileMode(0)}, DefaultFileMode, DefaultDirMode},
+		{"custom", []Option{WithFileMode(0o600)}, 0o600, 0o700},
+		{"group readable", []Option{WithFileMode(0o640)}, 0o640, 0o750},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := filepath.Join(t.TempDir(), "out")
+			path := filepath.Join(dir, "items.json")
+			dh := NewDataHandler(path, append([]Option{WithLogger(discardLogger)}, tt.opts...)...)
+			if _, err := dh.SaveItems([]models.Item{{ItemID: 1, Name: "Gadget Alpha", Value: 150.75}}); err != nil {
+				t.Fatal(err)
+			}
+
+			info, err := os.Stat(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := info.Mode().Perm(); got != tt.wantFile {
+				t.Errorf("file mode = %v, want %v", got, tt.wantFile)
+			}
+			info, err = os.Stat(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// Directories are subject to the umask, which never clears owner bits
+			// in practice, so the mode must be within wantDir with the owner bits intact.
+			if got := info.Mode().Perm(); got&^tt.wantDir != 0 || got&0o700 != tt.wantDir&0o700 {
+				t.Errorf("directory mode = %v, want %v less the umask", got, tt.wantDir)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+// tests/sample_project2/datahandler/health.go
+package datahandler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// Pinger is implemented by stores that can check their data source is
+// reachable without loading it. Callers should type-assert for it, since
+// not every DataStore supports a readiness check.
+type Pinger interface {
+	Ping() error
+}
+
+// Ping checks that the data file exists, is a regular file and can be opened
+// for reading; for a directory handler it checks that the directory can be
+// listed, and for a URL that a HEAD request, or failing that a GET, succeeds.
+// Nothing is decoded.
+func (dh *DataHandler) Ping() error {
+	if isURL(dh.dataSourcePath) {
+		return dh.pingURL()
+	}
+	info, err := os.Stat(dh.dataSourcePath)
+	if err != nil {
+		return fmt.Errorf("data source unavailable: %w", err)
+	}
+	if dh.isDir {
+		if !info.IsDir() {
+			return fmt.Errorf("data source %s is not a directory", dh.dataSourcePath)
+		}
+		if _, err := dh.dirFiles(); err != nil {
+			return fmt.Errorf("data source not readable: %w", err)
+		}
+		return nil
+	}
+	if !info.Mode().IsRegular() {
+		return fmt.Errorf("data source %s is not a regular file", dh.dataSourcePath)
+	}
+	f, err := os.Open(dh.dataSourcePath)
+	if err != nil {
+		return fmt.Errorf("data source not readable: %w", err)
+	}
+	return f.Close()
+}
+
+// pingURL sends a HEAD request to the data source URL. Feeds that serve only
+// GET often reject HEAD, e.g. with 405 or 403, so any non-2xx answer is
+// retried as a GET whose body is discarded; only the GET's outcome counts.
+func (dh *DataHandler) pingURL() error {
+	resp, err := dh.client().Head(dh.dataSourcePath)
+	if err != nil {
+		return fmt.Errorf("data source unavailable: %w", err)
+	}
+	var statusErr *StatusError
+	if err := checkStatus(resp); errors.As(err, &statusErr) {
+		body, err := dh.fetchURL(context.Background(), dh.dataSourcePath)
+		if err != nil {
+			return fmt.Errorf("data source unavailable: %w", err)
+		}
+		return body.Close()
+	}
+	return resp.Body.Close()
+}
+
+// Ping always succeeds; an in-memory store is always available.
+func (m *MemoryStore) Ping() error {
+	return nil
+}
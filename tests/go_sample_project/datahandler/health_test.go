@@ -0,0 +1,50 @@
+// tests/sample_project2/datahandler/health_test.go
+package datahandler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPingURL(t *testing.T) {
+	tests := []struct {
+		name       string
+		headStatus int
+		getStatus  int
+		wantStatus int // 0 means Ping succeeds
+	}{
+		{"head ok", http.StatusOK, http.StatusOK, 0},
+		{"get only, 405", http.StatusMethodNotAllowed, http.StatusOK, 0},
+		{"get only, 403", http.StatusForbidden, http.StatusOK, 0},
+		{"head not implemented", http.StatusNotImplemented, http.StatusOK, 0},
+		{"missing", http.StatusNotFound, http.StatusNotFound, http.StatusNotFound},
+		{"down", http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusServiceUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodHead {
+					w.WriteHeader(tt.headStatus)
+					return
+				}
+				w.WriteHeader(tt.getStatus)
+				w.Write([]byte(`[{"ItemID": 1, "Name": "Gadget Alpha", "Value": 150.75}]`))
+			}))
+			defer srv.Close()
+
+			err := NewDataHandler(srv.URL, WithLogger(discardLogger)).Ping()
+			if tt.wantStatus == 0 {
+				if err != nil {
+					t.Errorf("Ping: %v", err)
+				}
+				return
+			}
+			var statusErr *StatusError
+			if !errors.As(err, &statusErr) || statusErr.StatusCode != tt.wantStatus || statusErr.Method != http.MethodGet {
+				t.Errorf("Ping error = %v, want a GET *StatusError with status %d", err, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+// tests/sample_project2/datahandler/http.go
+package datahandler
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultHTTPTimeout bounds each request to an http:// or https:// data source
+// unless WithHTTPTimeout or WithHTTPClient says otherwise.
+const DefaultHTTPTimeout = 30 * time.Second
+
+// StatusError reports a non-2xx response from an HTTP data source.
+type StatusError struct {
+	Method     string
+	URL        string
+	StatusCode int
+	Status     string // e.g. "404 Not Found"
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%s %s: unexpected status %s", e.Method, e.URL, e.Status)
+}
+
+// Temporary reports whether the status suggests retrying may succeed
+// (429 Too Many Requests or any 5xx), so RetryPolicy retries such saves.
+func (e *StatusError) Temporary() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= 500
+}
+
+// isURL reports whether path names an HTTP(S) resource rather than a file.
+func isURL(path string) bool {
+	lower := strings.ToLower(path)
+	return strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://")
+}
+
+// client returns the HTTP client for URL data sources.
+func (dh *DataHandler) client() *http.Client {
+	if dh.httpClient != nil {
+		return dh.httpClient
+	}
+	timeout := dh.httpTimeout
+	if timeout <= 0 {
+		timeout = DefaultHTTPTimeout
+	}
+	return &http.Client{Timeout: timeout}
+}
+
+// fetchURL GETs url, aborting when ctx is done, and returns the response body; the caller must close it.
+func (dh *DataHandler) fetchURL(ctx context.Context, url string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+	resp, err := dh.client().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch data source: %w", err)
+	}
+	if err := checkStatus(resp); err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// postURL encodes the payload with encode and POSTs it to url.
+func (dh *DataHandler) postURL(url string, encode func(w io.Writer) error) error {
+	var body bytes.Buffer
+	if err := encode(&body); err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, url, &body)
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Content-Type", contentType(dh.format))
+	if dh.compression.isGzip(url) {
+		req.Header.Set("Content-Encoding", "gzip")
+	}
+	resp, err := dh.client().Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post items: %w", err)
+	}
+	return checkStatus(resp)
+}
+
+// checkStatus closes resp.Body and returns a *StatusError unless the status is 2xx.
+func checkStatus(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16)) // let the connection be reused
+	resp.Body.Close()
+	return &StatusError{
+		Method:     resp.Request.Method,
+		URL:        resp.Request.URL.Redacted(),
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+	}
+}
+
+// contentType returns the MIME type sent when posting items in format.
+func contentType(format Format) string {
+	switch format {
+	case FormatCSV:
+		return "text/csv"
+	case FormatNDJSON:
+		return "application/x-ndjson"
+	case FormatXML:
+		return "application/xml"
+	default:
+		return "application/json"
+	}
+}
@@ -0,0 +1,51 @@
+// tests/sample_project2/datahandler/memory.go
+package datahandler
+
+import (
+	"sourcelens/sampleproject2/models"
+	"sync"
+)
+
+// MemoryStore is a DataStore backed by in-memory slices, intended for tests
+// and for running the pipeline without touching the filesystem.
+// It is safe for concurrent use.
+type MemoryStore struct {
+	mu    sync.Mutex
+	items []models.Item
+	saved []models.Item
+}
+
+// Compile-time check that MemoryStore satisfies DataStore.
+var _ DataStore = (*MemoryStore)(nil)
+
+// NewMemoryStore is a constructor for a MemoryStore seeded with items.
+// The slice is copied, so later changes by the caller do not affect the store.
+func NewMemoryStore(items []models.Item) *MemoryStore {
+	return &MemoryStore{items: models.CloneAll(items)}
+}
+
+// LoadItems returns a copy of the seeded items.
+func (m *MemoryStore) LoadItems() ([]models.Item, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return models.CloneAll(m.items), nil
+}
+
+// SaveItems records a copy of items; retrieve it with Saved.
+func (m *MemoryStore) SaveItems(items []models.Item) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.saved = models.CloneAll(items)
+	if m.saved == nil {
+		m.saved = []models.Item{}
+	}
+	return len(items), nil
+}
+
+// Saved returns a copy of the items passed to the most recent SaveItems call,
+// or nil if nothing has been saved yet.
+func (m *MemoryStore) Saved() []models.Item {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return models.CloneAll(m.saved)
+}
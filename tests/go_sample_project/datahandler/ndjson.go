@@ -0,0 +1,61 @@
+// tests/sample_project2/datahandler/ndjson.go
+package datahandler
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sourcelens/sampleproject2/models"
+	"strings"
+)
+
+// isNDJSONPath reports whether path has a conventional newline-delimited JSON extension,
+// ignoring a trailing ".gz".
+func isNDJSONPath(path string) bool {
+	path = strings.TrimSuffix(strings.ToLower(path), ".gz")
+	switch filepath.Ext(path) {
+	case ".ndjson", ".jsonl":
+		return true
+	default:
+		return false
+	}
+}
+
+// streamNDJSON decodes one item per line from r and passes each to fn.
+// Blank lines are skipped; parse errors report the 1-based line number.
+func streamNDJSON(r io.Reader, fn func(models.Item) error) error {
+	br := bufio.NewReader(r)
+	for lineNo := 1; ; lineNo++ {
+		line, readErr := br.ReadBytes('\n')
+		if readErr != nil && !errors.Is(readErr, io.EOF) {
+			return readErr
+		}
+		if trimmed := bytes.TrimSpace(line); len(trimmed) > 0 {
+			var item models.Item
+			if err := json.Unmarshal(trimmed, &item); err != nil {
+				return fmt.Errorf("line %d: %w", lineNo, describeJSONError(err))
+			}
+			if err := fn(item); err != nil {
+				return err
+			}
+		}
+		if readErr != nil {
+			return nil
+		}
+	}
+}
+
+// encodeNDJSON writes each item to w as a single line of JSON.
+func encodeNDJSON(w io.Writer, items []models.Item) error {
+	enc := json.NewEncoder(w)
+	for _, item := range items {
+		if err := enc.Encode(item); err != nil {
+			return fmt.Errorf("failed to encode item %d: %w", item.ItemID, err)
+		}
+	}
+	return nil
+}
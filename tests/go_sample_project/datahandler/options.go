@@ -0,0 +1,128 @@
+// tests/sample_project2/datahandler/options.go
+package datahandler
+
+import (
+	"log/slog"
+	"net/http"
+	"os"
+	"time"
+)
+
+// Option configures a DataHandler at construction time.
+type Option func(*DataHandler)
+
+// WithFormat selects the serialization used by LoadItems and SaveItems.
+func WithFormat(format Format) Option {
+	return func(dh *DataHandler) {
+		dh.format = format
+	}
+}
+
+// WithCompression overrides the ".gz" extension check used to decide whether
+// the data file is gzip-compressed.
+func WithCompression(c Compression) Option {
+	return func(dh *DataHandler) {
+		dh.compression = c
+	}
+}
+
+// WithOutputPath makes SaveItems write to path instead of overwriting the data
+// source, so input files are never modified. It also allows saving from a
+// directory handler. An empty path keeps saving to the source.
+func WithOutputPath(path string) Option {
+	return func(dh *DataHandler) {
+		dh.outputPath = path
+	}
+}
+
+// WithBatchSize makes SaveItems split its input into files of at most n items;
+// see SaveBatches. Zero or negative disables batching.
+func WithBatchSize(n int) Option {
+	return func(dh *DataHandler) {
+		dh.batchSize = n
+	}
+}
+
+// WithFileMode sets the permissions of files written by SaveItems, e.g. 0600
+// for sensitive data. Directories it creates get the same mode plus execute
+// wherever read is allowed. Zero restores DefaultFileMode and DefaultDirMode.
+func WithFileMode(mode os.FileMode) Option {
+	return func(dh *DataHandler) {
+		dh.fileMode = mode
+	}
+}
+
+// WithSkipUnchangedSave makes SaveItems compare the encoded output with the
+// destination file's current contents and leave the file untouched, keeping
+// its modification time, when they are identical; SaveItemsIfChanged reports
+// whether that happened. The output is encoded twice when it did change.
+// It has no effect on http:// and https:// destinations.
+func WithSkipUnchangedSave() Option {
+	return func(dh *DataHandler) {
+		dh.skipUnchanged = true
+	}
+}
+
+// WithCSVDelimiter sets the field separator used to read and write CSV, e.g.
+// '\t' for tab-separated files. Zero restores the default comma; delimiters
+// encoding/csv rejects, such as '"' or '\n', make loads and saves fail.
+func WithCSVDelimiter(delim rune) Option {
+	return func(dh *DataHandler) {
+		dh.csvDelimiter = delim
+	}
+}
+
+// WithCSVBOM makes SaveItems start CSV output with a UTF-8 byte order mark so
+// spreadsheet programs such as Excel detect the encoding. A leading BOM is
+// always ignored when reading. It has no effect on other formats.
+func WithCSVBOM(bom bool) Option {
+	return func(dh *DataHandler) {
+		dh.csvBOM = bom
+	}
+}
+
+// WithPrettyPrint makes SaveItems indent JSON and XML output by indent per
+// level, e.g. "  " or "\t", so saved files diff cleanly. An empty indent keeps
+// the default compact output. Output always ends with a newline.
+// It has no effect on CSV or NDJSON.
+func WithPrettyPrint(indent string) Option {
+	return func(dh *DataHandler) {
+		dh.indent = indent
+	}
+}
+
+// WithLimit makes LoadItems return at most n items, taken from the start of
+// the file in order; the remainder is not read. The limit applies before
+// validation and deduplication, so fewer than n items may be returned.
+// n <= 0 means no limit.
+func WithLimit(n int) Option {
+	return func(dh *DataHandler) {
+		dh.limit = n
+	}
+}
+
+// WithLogger sets the logger used by the handler. A nil logger means slog.Default().
+func WithLogger(logger *slog.Logger) Option {
+	return func(dh *DataHandler) {
+		if logger == nil {
+			logger = slog.Default()
+		}
+		dh.logger = logger
+	}
+}
+
+// WithHTTPTimeout sets the per-request timeout used for http:// and https://
+// data sources. Zero or negative means DefaultHTTPTimeout.
+func WithHTTPTimeout(d time.Duration) Option {
+	return func(dh *DataHandler) {
+		dh.httpTimeout = d
+	}
+}
+
+// WithHTTPClient sets the client used for http:// and https:// data sources,
+// overriding WithHTTPTimeout. A nil client restores the default.
+func WithHTTPClient(c *http.Client) Option {
+	return func(dh *DataHandler) {
+		dh.httpClient = c
+	}
+}
@@ -0,0 +1,55 @@
+// tests/sample_project2/datahandler/page.go
+package datahandler
+
+import (
+	"context"
+	"fmt"
+	"sourcelens/sampleproject2/models"
+)
+
+// LoadItemsPage returns the window of at most limit items starting at the
+// zero-based position offset, in file order; limit <= 0 returns everything
+// from offset on. An offset past the end yields an empty slice, so callers
+// can resume from a saved cursor until a page comes back empty.
+// Items before the window are decoded but not kept, and nothing past it is read.
+// Validation and deduplication apply within the page only. The handler's
+// WithLimit setting is ignored.
+func (dh *DataHandler) LoadItemsPage(offset, limit int) ([]models.Item, error) {
+	if offset < 0 {
+		return nil, fmt.Errorf("invalid page offset %d: must not be negative", offset)
+	}
+	dh.logger.Info("Loading items page", "source", dh.dataSourcePath, "offset", offset, "limit", limit)
+
+	var items []models.Item
+	var err error
+	if dh.isDir {
+		items, err = dh.loadDirPage(offset, limit)
+	} else {
+		items, err = dh.loadFile(context.Background(), dh.dataSourcePath, offset, limit, true)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if items, err = deduplicate(items, dh.DeduplicationPolicy); err != nil {
+		return nil, &LoadError{Path: dh.dataSourcePath, Err: err}
+	}
+
+	dh.logger.Info("Loaded items page", "offset", offset, "count", len(items))
+	return items, nil
+}
+
+// loadDirPage loads every file in the directory and returns the requested window.
+func (dh *DataHandler) loadDirPage(offset, limit int) ([]models.Item, error) {
+	items, err := dh.loadDir(context.Background(), 0, true)
+	if err != nil {
+		return nil, err
+	}
+	if offset >= len(items) {
+		return []models.Item{}, nil
+	}
+	items = items[offset:]
+	if limit > 0 && limit < len(items) {
+		items = items[:limit]
+	}
+	return items, nil
+}
@@ -0,0 +1,68 @@
+// tests/sample_project2/datahandler/retry.go
+package datahandler
+
+import (
+	"errors"
+	"os"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy configures how SaveItems retries transient write failures.
+// The zero value disables retries.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first. Values <= 1 disable retries.
+	MaxAttempts int
+	// InitialBackoff is the wait before the second attempt; it doubles after each further failure.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the wait between attempts. Zero means no cap.
+	MaxBackoff time.Duration
+	// Sleep waits between attempts. Nil means time.Sleep; tests can inject a fake.
+	Sleep func(time.Duration)
+	// Retryable decides whether an error is worth retrying. Nil means IsRetryable.
+	Retryable func(error) bool
+}
+
+// IsRetryable reports whether err looks like a transient I/O failure
+// (interrupted or busy system calls, timeouts, or a generic I/O error).
+// Errors such as a missing directory or permission denied are not retryable.
+func IsRetryable(err error) bool {
+	var temporary interface{ Temporary() bool }
+	if errors.As(err, &temporary) && temporary.Temporary() {
+		return true
+	}
+	return errors.Is(err, os.ErrDeadlineExceeded) ||
+		errors.Is(err, syscall.EAGAIN) ||
+		errors.Is(err, syscall.EBUSY) ||
+		errors.Is(err, syscall.EINTR) ||
+		errors.Is(err, syscall.EIO) ||
+		errors.Is(err, syscall.ETIMEDOUT)
+}
+
+// do runs op, retrying according to the policy. It returns the last error if all attempts fail.
+func (rp RetryPolicy) do(op func() error, onRetry func(attempt int, wait time.Duration, err error)) error {
+	sleep := rp.Sleep
+	if sleep == nil {
+		sleep = time.Sleep
+	}
+	retryable := rp.Retryable
+	if retryable == nil {
+		retryable = IsRetryable
+	}
+
+	wait := rp.InitialBackoff
+	for attempt := 1; ; attempt++ {
+		err := op()
+		if err == nil || attempt >= rp.MaxAttempts || !retryable(err) {
+			return err
+		}
+		if onRetry != nil {
+			onRetry(attempt, wait, err)
+		}
+		sleep(wait)
+		wait *= 2
+		if rp.MaxBackoff > 0 && wait > rp.MaxBackoff {
+			wait = rp.MaxBackoff
+		}
+	}
+}
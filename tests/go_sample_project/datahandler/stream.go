@@ -0,0 +1,95 @@
+// tests/sample_project2/datahandler/stream.go
+package datahandler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sourcelens/sampleproject2/models"
+)
+
+// StreamItems decodes the JSON array in the file at path one element at a time,
+// calling fn for each item, so memory use stays flat regardless of file size.
+// Files named *.ndjson or *.jsonl are read as JSON Lines instead, and files
+// ending in ".gz" are decompressed transparently. Streaming stops at the
+// first error returned by fn, which is returned unwrapped.
+func StreamItems(path string, fn func(models.Item) error) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open data file: %w", err)
+	}
+	defer f.Close()
+
+	var src io.Reader = f
+	if CompressionAuto.isGzip(path) {
+		zr, err := newGzipReader(f)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		defer zr.Close()
+		src = zr
+	}
+
+	var callbackErr error
+	stream := streamJSON
+	if isNDJSONPath(path) {
+		stream = streamNDJSON
+	}
+	err = stream(src, func(item models.Item) error {
+		callbackErr = fn(item)
+		return callbackErr
+	})
+	if err != nil && callbackErr == nil {
+		return fmt.Errorf("failed to stream items from %s: %w", path, err)
+	}
+	return err
+}
+
+// streamJSON walks a JSON array token by token, decoding and passing each element to fn.
+func streamJSON(r io.Reader, fn func(models.Item) error) error {
+	dec := json.NewDecoder(r)
+
+	if err := expectDelim(dec, '['); err != nil {
+		return err
+	}
+	for index := 0; dec.More(); index++ {
+		var item models.Item
+		if err := dec.Decode(&item); err != nil {
+			return fmt.Errorf("item at index %d: %w", index, describeJSONError(err))
+		}
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+	if err := expectDelim(dec, ']'); err != nil {
+		return err
+	}
+	return expectEOF(dec)
+}
+
+// expectDelim reads the next token and checks that it is the delimiter want.
+func expectDelim(dec *json.Decoder, want json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return describeJSONError(err)
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != want {
+		return fmt.Errorf("expected %q at byte offset %d, got %v", want, dec.InputOffset(), tok)
+	}
+	return nil
+}
+
+// expectEOF checks that nothing but whitespace follows the decoded value.
+func expectEOF(dec *json.Decoder) error {
+	_, err := dec.Token()
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+	var syntaxErr *json.SyntaxError
+	if err != nil && !errors.As(err, &syntaxErr) {
+		return err // The reader itself failed, e.g. a corrupt gzip stream.
+	}
+	return fmt.Errorf("unexpected data after JSON array at byte offset %d", dec.InputOffset())
+}
@@ -0,0 +1,39 @@
+// tests/sample_project2/datahandler/unchanged.go
+package datahandler
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"io"
+	"os"
+	"sourcelens/sampleproject2/models"
+)
+
+// SaveItemsIfChanged is SaveItems that also reports whether the destination
+// was left alone because WithSkipUnchangedSave found its contents already up
+// to date. For a batched save every part must have been unchanged. Without
+// WithSkipUnchangedSave skipped is always false.
+func (dh *DataHandler) SaveItemsIfChanged(items []models.Item) (saved int, skipped bool, err error) {
+	return dh.save(items)
+}
+
+// unchanged reports whether the file at path already holds exactly the bytes
+// encode would write, comparing SHA-256 hashes so neither side is held in
+// memory. A missing or unreadable file counts as changed.
+func unchanged(path string, encode func(w io.Writer) error) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	current := sha256.New()
+	if _, err := io.Copy(current, f); err != nil {
+		return false
+	}
+	next := sha256.New()
+	if err := encode(next); err != nil {
+		return false // Let the real write report the error.
+	}
+	return bytes.Equal(current.Sum(nil), next.Sum(nil))
+}
@@ -0,0 +1,210 @@
+// tests/sample_project2/datahandler/xml.go
+package datahandler
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"sourcelens/sampleproject2/models"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Element names of the XML document layout.
+const (
+	xmlRootElement = "items"
+	xmlItemElement = "item"
+)
+
+// xmlItem is the wire form of an item. Fields are read as text so that a bad
+// value can be reported together with the element it came from.
+type xmlItem struct {
+	ItemID      string   `xml:"ItemID"`
+	Name        string   `xml:"Name"`
+	Value       string   `xml:"Value"`
+	Processed   string   `xml:"Processed,omitempty"`
+	Category    string   `xml:"Category,omitempty"`
+	ProcessedAt string   `xml:"ProcessedAt,omitempty"`
+	Tags        *xmlTags `xml:"Tags,omitempty"`
+	Reason      string   `xml:"ProcessedReason,omitempty"`
+	Normalized  string   `xml:"NormalizedValue,omitempty"`
+	Delta       string   `xml:"Delta,omitempty"`
+}
+
+// xmlTags is the <Tags><Tag>..</Tag></Tags> wrapper. It is a pointer in
+// xmlItem so that an item without tags has no <Tags> element at all.
+type xmlTags struct {
+	Tag []string `xml:"Tag"`
+}
+
+// streamXML reads <item> elements from an <items> document one at a time and
+// passes each to fn. Namespaces are ignored.
+func streamXML(r io.Reader, fn func(models.Item) error) error {
+	dec := xml.NewDecoder(r)
+
+	root, err := nextStartElement(dec)
+	if errors.Is(err, io.EOF) {
+		return fmt.Errorf("malformed XML: document has no <%s> element", xmlRootElement)
+	}
+	if err != nil {
+		return describeXMLError(dec, "", err)
+	}
+	if root.Name.Local != xmlRootElement {
+		return fmt.Errorf("malformed XML: expected root element <%s>, got <%s>", xmlRootElement, root.Name.Local)
+	}
+
+	for index := 0; ; index++ {
+		tok, err := dec.Token()
+		if err != nil {
+			return describeXMLError(dec, xmlRootElement, err)
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if t.Name.Local != xmlItemElement {
+				return fmt.Errorf("malformed XML at line %d: unexpected element <%s> in <%s>", lineOf(dec), t.Name.Local, xmlRootElement)
+			}
+			var raw xmlItem
+			if err := dec.DecodeElement(&raw, &t); err != nil {
+				return fmt.Errorf("item at index %d: %w", index, describeXMLError(dec, xmlItemElement, err))
+			}
+			item, err := raw.toItem()
+			if err != nil {
+				return fmt.Errorf("item at index %d: %w", index, err)
+			}
+			if err := fn(item); err != nil {
+				return err
+			}
+		case xml.EndElement:
+			return nil // </items>; anything after the root element is ignored
+		default:
+			index-- // whitespace, comments and the like are not items
+		}
+	}
+}
+
+// nextStartElement skips the prolog and returns the first start element.
+func nextStartElement(dec *xml.Decoder) (xml.StartElement, error) {
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return xml.StartElement{}, err
+		}
+		if start, ok := tok.(xml.StartElement); ok {
+			return start, nil
+		}
+	}
+}
+
+// describeXMLError adds the line number and the enclosing element to a decoder error.
+func describeXMLError(dec *xml.Decoder, element string, err error) error {
+	if errors.Is(err, io.EOF) {
+		err = io.ErrUnexpectedEOF
+	}
+	var syntaxErr *xml.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		err = errors.New(syntaxErr.Msg)
+	}
+	if element == "" {
+		return fmt.Errorf("malformed XML at line %d: %w", lineOf(dec), err)
+	}
+	return fmt.Errorf("malformed XML in <%s> at line %d: %w", element, lineOf(dec), err)
+}
+
+// lineOf returns the decoder's current 1-based line number.
+func lineOf(dec *xml.Decoder) int {
+	line, _ := dec.InputPos()
+	return line
+}
+
+// toItem parses the text fields of x into an Item.
+func (x xmlItem) toItem() (models.Item, error) {
+	var item models.Item
+
+	id, err := strconv.Atoi(strings.TrimSpace(x.ItemID))
+	if err != nil {
+		return item, fmt.Errorf("invalid <ItemID>: %w", err)
+	}
+	value, err := strconv.ParseFloat(strings.TrimSpace(x.Value), 64)
+	if err != nil {
+		return item, fmt.Errorf("invalid <Value>: %w", err)
+	}
+	item = *models.NewItem(id, x.Name, value)
+	item.Category = x.Category
+	item.ProcessedReason = x.Reason
+	if raw := strings.TrimSpace(x.Normalized); raw != "" {
+		if item.NormalizedValue, err = strconv.ParseFloat(raw, 64); err != nil {
+			return item, fmt.Errorf("invalid <NormalizedValue>: %w", err)
+		}
+	}
+	if raw := strings.TrimSpace(x.Delta); raw != "" {
+		if item.Delta, err = strconv.ParseFloat(raw, 64); err != nil {
+			return item, fmt.Errorf("invalid <Delta>: %w", err)
+		}
+	}
+	if x.Tags != nil && len(x.Tags.Tag) > 0 {
+		item.Tags = x.Tags.Tag
+	}
+
+	if raw := strings.TrimSpace(x.Processed); raw != "" {
+		if item.Processed, err = strconv.ParseBool(raw); err != nil {
+			return item, fmt.Errorf("invalid <Processed>: %w", err)
+		}
+	}
+	if raw := strings.TrimSpace(x.ProcessedAt); raw != "" {
+		if item.ProcessedAt, err = time.Parse(time.RFC3339Nano, raw); err != nil {
+			return item, fmt.Errorf("invalid <ProcessedAt>: %w", err)
+		}
+	}
+	return item, nil
+}
+
+// encodeXML writes items to w as an <items> document with an XML declaration,
+// each level indented by indent, or compact when indent is empty.
+// Processed, Category, ProcessedAt, Tags, ProcessedReason, NormalizedValue and
+// Delta are omitted when unset.
+func encodeXML(w io.Writer, items []models.Item, indent string) error {
+	doc := struct {
+		XMLName xml.Name  `xml:"items"`
+		Items   []xmlItem `xml:"item"`
+	}{Items: make([]xmlItem, 0, len(items))}
+	for _, item := range items {
+		x := xmlItem{
+			ItemID:   strconv.Itoa(item.ItemID),
+			Name:     item.Name,
+			Value:    strconv.FormatFloat(item.Value, 'f', -1, 64),
+			Category: item.Category,
+			Reason:   item.ProcessedReason,
+		}
+		if len(item.Tags) > 0 {
+			x.Tags = &xmlTags{Tag: item.Tags}
+		}
+		if item.Processed {
+			x.Processed = "true"
+		}
+		if item.NormalizedValue != 0 {
+			x.Normalized = strconv.FormatFloat(item.NormalizedValue, 'f', -1, 64)
+		}
+		if item.Delta != 0 {
+			x.Delta = strconv.FormatFloat(item.Delta, 'f', -1, 64)
+		}
+		if !item.ProcessedAt.IsZero() {
+			x.ProcessedAt = item.ProcessedAt.Format(time.RFC3339Nano)
+		}
+		doc.Items = append(doc.Items, x)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	if indent != "" {
+		enc.Indent("", indent)
+	}
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("failed to encode items as XML: %w", err)
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
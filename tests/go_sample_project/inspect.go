@@ -0,0 +1,62 @@
+// tests/sample_project2/inspect.go
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sourcelens/sampleproject2/config"
+	"sourcelens/sampleproject2/datahandler"
+	"sourcelens/sampleproject2/itemprocessor"
+	"sourcelens/sampleproject2/models"
+)
+
+// printItemStats loads items from store and writes a summary of their values
+// to w without processing or saving anything.
+func printItemStats(w io.Writer, store datahandler.DataStore, cfg *config.Config) error {
+	items, err := store.LoadItems()
+	if err != nil {
+		return fmt.Errorf("failed to load items: %w", err)
+	}
+
+	fmt.Fprintf(w, "Items:             %d\n", len(items))
+	if len(items) == 0 {
+		return nil
+	}
+
+	minValue, maxValue, err := models.ValueRange(items)
+	if err != nil {
+		return err
+	}
+	average, err := models.AverageValue(items)
+	if err != nil {
+		return err
+	}
+
+	ip := itemprocessor.NewItemProcessor(cfg.Threshold)
+	exceeding := 0
+	for i := range items {
+		if ip.Exceeds(&items[i]) {
+			exceeding++
+		}
+	}
+
+	fmt.Fprintf(w, "Min value:         %.2f\n", minValue)
+	fmt.Fprintf(w, "Max value:         %.2f\n", maxValue)
+	fmt.Fprintf(w, "Average value:     %.2f\n", average)
+	fmt.Fprintf(w, "Total value:       %.2f\n", models.SumValues(items))
+	fmt.Fprintf(w, "Exceed threshold:  %d (threshold %d)\n", exceeding, cfg.Threshold)
+
+	groups := models.GroupByCategory(items)
+	categories := make([]string, 0, len(groups))
+	for category := range groups {
+		categories = append(categories, category)
+	}
+	sort.Strings(categories)
+	fmt.Fprintln(w, "By category:")
+	for _, category := range categories {
+		group := groups[category]
+		fmt.Fprintf(w, "  %-16s %d items, total value %.2f\n", category+":", len(group), models.SumValues(group))
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+// tests/sample_project2/itemprocessor/audit.go
+package itemprocessor
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sourcelens/sampleproject2/models"
+	"sync"
+	"time"
+)
+
+// AuditRecord is one line of the audit log written for every item marked as processed.
+type AuditRecord struct {
+	Event       string    `json:"event"`
+	ItemID      int       `json:"item_id"`
+	ProcessedAt time.Time `json:"processed_at"`
+	Category    string    `json:"category"`
+	Reason      string    `json:"reason,omitempty"`
+}
+
+// auditEventProcessed is the Event of records written by ProcessItem.
+const auditEventProcessed = "processed"
+
+// auditLog serializes AuditRecords as JSON Lines to a writer. Each record is
+// written with a single Write call under a mutex, so lines from concurrent
+// workers never interleave.
+type auditLog struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// record appends an entry for item. A nil auditLog does nothing.
+func (a *auditLog) record(item *models.Item) error {
+	if a == nil {
+		return nil
+	}
+	line, err := json.Marshal(AuditRecord{
+		Event:       auditEventProcessed,
+		ItemID:      item.ItemID,
+		ProcessedAt: item.ProcessedAt,
+		Category:    item.Category,
+		Reason:      item.ProcessedReason,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode audit record for item %d: %w", item.ItemID, err)
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if _, err := a.w.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write audit record for item %d: %w", item.ItemID, err)
+	}
+	return nil
+}
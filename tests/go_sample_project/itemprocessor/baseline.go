@@ -0,0 +1,28 @@
+// tests/sample_project2/itemprocessor/baseline.go
+package itemprocessor
+
+import (
+	"math"
+	"sourcelens/sampleproject2/models"
+)
+
+// baseline holds the previous snapshot set with WithBaseline.
+type baseline struct {
+	values  map[int]float64
+	percent float64
+}
+
+// compare returns how far item's Value moved from its previous value, whether
+// the move exceeds the configured percent, and whether the item is new.
+// A previous value of 0 counts any non-zero delta as a change.
+func (b *baseline) compare(item *models.Item) (delta float64, changed, isNew bool) {
+	prev, ok := b.values[item.ItemID]
+	if !ok {
+		return 0, false, true
+	}
+	delta = item.Value - prev
+	if prev == 0 {
+		return delta, delta != 0, false
+	}
+	return delta, math.Abs(delta) > math.Abs(prev)*b.percent/100, false
+}
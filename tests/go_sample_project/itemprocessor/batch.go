@@ -0,0 +1,85 @@
+// tests/sample_project2/itemprocessor/batch.go
+package itemprocessor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"sourcelens/sampleproject2/models"
+	"sync"
+)
+
+// ProcessBatch processes items concurrently using at most workers goroutines.
+// A workers value <= 0 uses runtime.GOMAXPROCS(0). Each item is handled by
+// exactly one worker, so the MarkAsProcessed side effect never races.
+// Errors from individual items are collected and returned joined, in input order.
+// If ctx is canceled no further items are dispatched and ctx.Err() is returned.
+func (p *ItemProcessor) ProcessBatch(ctx context.Context, items []*models.Item, workers int) error {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > len(items) {
+		workers = len(items)
+	}
+
+	errs := make([]error, len(items))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				if _, err := p.ProcessItem(ctx, items[i]); err != nil {
+					errs[i] = fmt.Errorf("item %d: %w", items[i].ItemID, err)
+				}
+			}
+		}()
+	}
+
+dispatch:
+	for i := range items {
+		select {
+		case indexes <- i:
+		case <-ctx.Done():
+			break dispatch
+		}
+	}
+	close(indexes)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return errors.Join(errs...)
+}
+
+// streamBufferSize caps the ProcessStream channel buffer so a slow consumer
+// applies back-pressure instead of the whole batch completing unobserved.
+const streamBufferSize = 64
+
+// ProcessStream processes items sequentially in a background goroutine and
+// delivers one ProcessResult per item on the returned channel, which is closed
+// when all items are done. Per-item failures are reported in ProcessResult.Err.
+// Canceling ctx stops processing and closes the channel promptly, even if the
+// consumer has stopped reading.
+func (p *ItemProcessor) ProcessStream(ctx context.Context, items []*models.Item) <-chan ProcessResult {
+	results := make(chan ProcessResult, min(len(items), streamBufferSize))
+	go func() {
+		defer close(results)
+		for _, item := range items {
+			if ctx.Err() != nil {
+				return
+			}
+			result, err := p.ProcessItem(ctx, item)
+			result.Err = err
+			select {
+			case results <- result:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return results
+}
@@ -0,0 +1,46 @@
+// tests/sample_project2/itemprocessor/batch_test.go
+package itemprocessor
+
+import (
+	"context"
+	"errors"
+	"sourcelens/sampleproject2/models"
+	"strings"
+	"testing"
+)
+
+func TestProcessBatchPointersIntoSlice(t *testing.T) {
+	const n = 200
+	errBad := errors.New("bad item")
+	p := NewItemProcessor(100, WithSilent(), WithValidator(func(item *models.Item) error {
+		if item.ItemID%50 == 0 {
+			return errBad
+		}
+		return nil
+	}))
+
+	items := make([]models.Item, n)
+	ptrs := make([]*models.Item, n)
+	for i := range items {
+		items[i] = models.Item{ItemID: i + 1, Name: "Item", Value: float64(i)}
+		ptrs[i] = &items[i]
+	}
+
+	err := p.ProcessBatch(context.Background(), ptrs, 8)
+	if !errors.Is(err, errBad) {
+		t.Fatalf("ProcessBatch error = %v, want it to wrap %v", err, errBad)
+	}
+	// Errors are joined in input order, one line per failed item.
+	lines := strings.Split(err.Error(), "\n")
+	if len(lines) != n/50 || !strings.HasPrefix(lines[0], "item 50: ") {
+		t.Errorf("ProcessBatch error = %q, want %d errors starting with item 50", err, n/50)
+	}
+	for _, item := range items {
+		if want := item.ItemID%50 != 0; item.Processed != want {
+			t.Errorf("item %d Processed = %v, want %v", item.ItemID, item.Processed, want)
+		}
+	}
+	if got := p.Stats().ProcessedCount; got != n-n/50 {
+		t.Errorf("ProcessedCount = %d, want %d", got, n-n/50)
+	}
+}
@@ -0,0 +1,49 @@
+// tests/sample_project2/itemprocessor/chain.go
+package itemprocessor
+
+import (
+	"context"
+	"fmt"
+	"sourcelens/sampleproject2/models"
+)
+
+// chain is the Processor returned by Chain.
+type chain []Processor
+
+// Chain returns a Processor that passes every item through procs in order,
+// e.g. a validating, a transforming and a categorizing stage. It stops at the
+// first stage that fails and returns that error, naming the stage by its
+// 0-based index. The result is that of the last stage that ran, with Duration
+// summed over all stages. The chain keeps no statistics of its own, so a
+// pipeline counts its results itself. An empty chain leaves items untouched.
+func Chain(procs ...Processor) Processor {
+	return append(chain(nil), procs...)
+}
+
+// ValidateItem implements ItemValidator by asking every stage that implements
+// it, in order, against the unmodified item; stages that do not are skipped.
+func (c chain) ValidateItem(item *models.Item) error {
+	for i, proc := range c {
+		if v, ok := proc.(ItemValidator); ok {
+			if err := v.ValidateItem(item); err != nil {
+				return fmt.Errorf("stage %d: %w", i, err)
+			}
+		}
+	}
+	return nil
+}
+
+// ProcessItem implements Processor.
+func (c chain) ProcessItem(ctx context.Context, item *models.Item) (ProcessResult, error) {
+	result := ProcessResult{ItemID: item.ItemID}
+	for i, proc := range c {
+		elapsed := result.Duration
+		stage, err := proc.ProcessItem(ctx, item)
+		result = stage
+		result.Duration += elapsed
+		if err != nil {
+			return result, fmt.Errorf("stage %d: %w", i, err)
+		}
+	}
+	return result, nil
+}
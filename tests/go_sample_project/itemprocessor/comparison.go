@@ -0,0 +1,76 @@
+// tests/sample_project2/itemprocessor/comparison.go
+package itemprocessor
+
+import "fmt"
+
+// ComparisonOp selects how ProcessItem compares an item's value against the threshold.
+type ComparisonOp int
+
+const (
+	// GreaterThan matches values strictly above the threshold (the default).
+	GreaterThan ComparisonOp = iota
+	// GreaterOrEqual matches values at or above the threshold.
+	GreaterOrEqual
+	// LessThan matches values strictly below the threshold.
+	LessThan
+)
+
+// String returns the operator symbol.
+func (op ComparisonOp) String() string {
+	switch op {
+	case GreaterThan:
+		return ">"
+	case GreaterOrEqual:
+		return ">="
+	case LessThan:
+		return "<"
+	default:
+		return fmt.Sprintf("ComparisonOp(%d)", int(op))
+	}
+}
+
+// matches reports whether value satisfies the comparison against threshold.
+func (op ComparisonOp) matches(value, threshold float64) bool {
+	switch op {
+	case GreaterOrEqual:
+		return value >= threshold
+	case LessThan:
+		return value < threshold
+	default:
+		return value > threshold
+	}
+}
+
+// category returns the category assigned to an item for the comparison outcome.
+// A match is reported as CategoryLow for LessThan and CategoryHigh otherwise.
+func (op ComparisonOp) category(matched bool) string {
+	switch {
+	case !matched:
+		return CategoryNormal
+	case op == LessThan:
+		return CategoryLow
+	default:
+		return CategoryHigh
+	}
+}
+
+// describe returns the log message for the comparison outcome.
+func (op ComparisonOp) describe(matched bool) string {
+	switch op {
+	case GreaterOrEqual:
+		if matched {
+			return "Item value is at or above threshold"
+		}
+		return "Item value is below threshold"
+	case LessThan:
+		if matched {
+			return "Item value is below threshold"
+		}
+		return "Item value is at or above threshold"
+	default:
+		if matched {
+			return "Item value exceeds threshold"
+		}
+		return "Item value is within threshold"
+	}
+}
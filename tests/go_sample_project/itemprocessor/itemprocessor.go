@@ -2,33 +2,338 @@
 package itemprocessor
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"log"
+	"log/slog"
+	"math"
 	"sourcelens/sampleproject2/models"
+	"sync"
+	"text/template"
+	"time"
 )
 
+// Categories assigned to Item.Category by ProcessItem based on the threshold
+// comparison, unless the item's Category has a WithCategoryThresholds entry.
+const (
+	CategoryHigh   = "high"
+	CategoryLow    = "low"
+	CategoryNormal = "normal"
+)
+
+// ProcessResult describes the outcome of processing a single item.
+type ProcessResult struct {
+	ItemID int
+	// ExceededThreshold is true when the value satisfied the processor's ComparisonOp.
+	ExceededThreshold bool
+	Category          string
+	// Duration is how long the item's rules took to run, measured with the
+	// monotonic clock. It is set even when a rule fails.
+	Duration time.Duration
+	// Skipped is true when WithSkipProcessed left an already processed item untouched.
+	Skipped bool
+	// Delta, Changed and New report the WithBaseline comparison: the change
+	// from the previous value, whether it exceeded the configured percent and
+	// whether the item was absent from the baseline.
+	Delta   float64
+	Changed bool
+	New     bool
+	// Err is the processing error for this item; only ProcessStream sets it.
+	Err error
+}
+
+// RuleFunc is a custom validation or transformation step applied to an item.
+// Returning an error stops processing of that item.
+type RuleFunc func(item *models.Item) error
+
+// Rule is a named RuleFunc, registered with AddRule or WithRules.
+type Rule struct {
+	Name string
+	Func RuleFunc
+}
+
 // ItemProcessor processes individual Item objects.
+// It is safe for concurrent use; rules and statistics are guarded by a mutex.
 type ItemProcessor struct {
-	threshold int
+	threshold     int
+	thresholdFunc func(*models.Item) float64
+	catThreshold  map[string]int
+	validator     func(*models.Item) error
+	op            ComparisonOp
+	cents         bool
+	skipProcessed bool
+	passthrough   bool
+	timeout       time.Duration
+	limiter       *rateLimiter
+	rounding      bool
+	places        int
+	audit         *auditLog
+	baseline      *baseline
+	template      *template.Template
+	logger        *slog.Logger
+
+	mu    sync.Mutex
+	rules []Rule
+	hooks []func(*models.Item)
+
+	stats   StatsCollector
+	metrics metrics
 }
 
 // NewItemProcessor is a constructor for the ItemProcessor.
-func NewItemProcessor(threshold int) *ItemProcessor {
-	log.Printf("ItemProcessor initialized with threshold: %d", threshold)
-	return &ItemProcessor{threshold: threshold}
+// By default it logs to slog.Default(), compares with GreaterThan and has no rules;
+// use the With* options to change this.
+func NewItemProcessor(threshold int, opts ...Option) *ItemProcessor {
+	p := &ItemProcessor{threshold: threshold, op: GreaterThan, logger: slog.Default()}
+	for _, opt := range opts {
+		opt(p)
+	}
+	p.logger.Info("ItemProcessor initialized", "threshold", threshold, "op", p.op)
+	return p
+}
+
+// AddRule registers a rule that ProcessItem runs on every item, in registration
+// order, before the threshold comparison. Rules may modify the item.
+func (p *ItemProcessor) AddRule(name string, fn RuleFunc) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.rules = append(p.rules, Rule{Name: name, Func: fn})
+}
+
+// OnProcessed registers a callback that ProcessItem invokes with each item
+// after marking it as processed, e.g. to send a webhook. Callbacks run
+// synchronously in registration order. A panicking callback is recovered and
+// logged; the remaining callbacks still run and the item still succeeds.
+func (p *ItemProcessor) OnProcessed(fn func(*models.Item)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.hooks = append(p.hooks, fn)
+}
+
+// runHook calls an OnProcessed callback, recovering from any panic.
+func (p *ItemProcessor) runHook(index int, hook func(*models.Item), item *models.Item) {
+	defer func() {
+		if r := recover(); r != nil {
+			p.logger.Error("OnProcessed callback panicked", "item_id", item.ItemID, "callback", index, "panic", r)
+		}
+	}()
+	hook(item)
 }
 
 // ProcessItem processes a single item, marking it as processed.
 // Takes a pointer to an Item to allow modification.
-func (p *ItemProcessor) ProcessItem(item *models.Item) (bool, error) {
-	log.Printf("Processing item ID: %d, Name: '%s', Value: %.2f", item.ItemID, item.Name, item.Value)
+// A WithValidator check runs first, then the registered rules; if either fails
+// the item is not marked as processed and the error is returned wrapped.
+// If ctx is already canceled the item is left untouched and ctx.Err() is returned.
+// With WithRateLimit it first waits for its turn, returning ctx.Err() if ctx ends meanwhile.
+// With WithSkipProcessed an already processed item is returned untouched with Skipped set.
+// A NewPassthrough processor only counts the item.
+func (p *ItemProcessor) ProcessItem(ctx context.Context, item *models.Item) (ProcessResult, error) {
+	result := ProcessResult{ItemID: item.ItemID}
+	if p.passthrough {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		p.stats.Add(Stats{TotalItems: 1})
+		result.Category = item.Category
+		p.record(item, result)
+		return result, nil
+	}
+	if p.skipProcessed && item.Processed {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		p.stats.Add(Stats{AlreadyProcessedCount: 1})
+		result.Skipped = true
+		result.Category = item.Category
+		return result, nil
+	}
+	if err := p.limiter.wait(ctx); err != nil {
+		return result, err
+	}
+	p.stats.Add(Stats{TotalItems: 1})
+	p.mu.Lock()
+	rules := p.rules
+	hooks := p.hooks
+	p.mu.Unlock()
+
+	// Per-item logging is guarded so that a disabled logger (see WithSilent)
+	// costs nothing, not even boxing the attribute values.
+	if p.logger.Enabled(ctx, slog.LevelDebug) {
+		p.logger.Debug("Processing item", "item_id", item.ItemID, "name", item.Name, "value", item.Value)
+	}
+
+	if p.validator != nil {
+		if err := p.validator(item); err != nil {
+			p.metrics.errors.Add(1)
+			return result, fmt.Errorf("validation failed for item %d: %w", item.ItemID, err)
+		}
+	}
+	start := time.Now()
+	err := p.runRules(ctx, rules, item)
+	result.Duration = time.Since(start)
+	if err != nil {
+		p.metrics.errors.Add(1)
+		return result, err
+	}
+
+	if p.rounding {
+		item.Value, _ = models.Round(item.Value, p.places) // places >= 0, checked by WithRounding
+	}
+
+	if p.baseline != nil {
+		result.Delta, result.Changed, result.New = p.baseline.compare(item)
+		item.Delta = result.Delta
+	}
 
-	if item.Value > float64(p.threshold) {
-		fmt.Printf("Item '%s' (ID: %d) value %.2f exceeds threshold %d.\n", item.Name, item.ItemID, item.Value, p.threshold)
-	} else {
-		fmt.Printf("Item '%s' (ID: %d) value %.2f is within threshold %d.\n", item.Name, item.ItemID, item.Value, p.threshold)
+	threshold := p.thresholdFor(item)
+	result.ExceededThreshold = p.matches(item, threshold)
+	result.Category = p.op.category(result.ExceededThreshold)
+	if p.logger.Enabled(ctx, slog.LevelInfo) {
+		p.logger.Info(p.message(item, threshold, result.ExceededThreshold),
+			"item_id", item.ItemID, "name", item.Name, "value", item.Value, "op", p.op, "threshold", threshold)
 	}
 
+	if _, ok := p.categoryThreshold(item); !ok {
+		item.Category = result.Category
+	}
 	item.MarkAsProcessed()
-	return true, nil
-}
\ No newline at end of file
+	item.AddTag(models.TagProcessed)
+	if result.Changed {
+		item.AddTag(models.TagChanged)
+	}
+	if result.New {
+		item.AddTag(models.TagNew)
+	}
+	p.record(item, result)
+	if err := p.audit.record(item); err != nil {
+		// The item is already processed; a broken audit sink is reported, not fatal.
+		p.logger.Error("Audit log write failed", "item_id", item.ItemID, "error", err)
+	}
+	for i, hook := range hooks {
+		p.runHook(i, hook, item)
+	}
+	return result, nil
+}
+
+// runRules applies rules to item in order. Without a timeout they run inline.
+// With one, they run on a copy of the item in a separate goroutine bounded by
+// context.WithTimeout; the copy is written back only if every rule succeeds in
+// time, so a rule that overruns cannot modify the item after ProcessItem returns.
+func (p *ItemProcessor) runRules(ctx context.Context, rules []Rule, item *models.Item) error {
+	if p.timeout <= 0 || len(rules) == 0 {
+		return applyRules(rules, item)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	// Clone rather than copy, so the rule cannot reach item's Tags either.
+	working := item.Clone()
+	done := make(chan error, 1) // Buffered so an overrunning rule's goroutine can still exit.
+	go func() { done <- applyRules(rules, &working) }()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			return err
+		}
+		*item = working
+		return nil
+	case <-ctx.Done():
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return fmt.Errorf("processing item %d timed out after %s: %w", item.ItemID, p.timeout, ctx.Err())
+		}
+		return ctx.Err()
+	}
+}
+
+// applyRules runs rules on item in order, stopping at the first failure. It is
+// a plain function rather than a closure so the inline path does not allocate.
+func applyRules(rules []Rule, item *models.Item) error {
+	for _, r := range rules {
+		if err := r.Func(item); err != nil {
+			return fmt.Errorf("rule %q failed for item %d: %w", r.Name, item.ItemID, err)
+		}
+	}
+	return nil
+}
+
+// ValidateItem reports whether ProcessItem would reject item before running
+// its rules because the WithValidator check fails. The validator sees a copy,
+// and neither the item nor the statistics are changed. Items that
+// WithSkipProcessed or NewPassthrough would leave alone always pass.
+func (p *ItemProcessor) ValidateItem(item *models.Item) error {
+	if p.passthrough || (p.skipProcessed && item.Processed) {
+		return nil
+	}
+	if p.validator != nil {
+		clone := item.Clone()
+		if err := p.validator(&clone); err != nil {
+			return fmt.Errorf("validation failed for item %d: %w", item.ItemID, err)
+		}
+	}
+	return nil
+}
+
+// Exceeds reports whether item satisfies the processor's threshold comparison.
+// It has no side effects, so it can be used to preview results without processing.
+func (p *ItemProcessor) Exceeds(item *models.Item) bool {
+	return p.matches(item, p.thresholdFor(item))
+}
+
+// matches applies the comparison operator to item against threshold, in
+// whole cents when WithCentsComparison is set.
+func (p *ItemProcessor) matches(item *models.Item, threshold float64) bool {
+	if p.cents {
+		valueCents := models.ValueCents(*item)
+		thresholdCents := int64(math.Round(threshold * 100))
+		return p.op.matches(float64(valueCents), float64(thresholdCents))
+	}
+	return p.op.matches(item.Value, threshold)
+}
+
+// thresholdFor returns the threshold that applies to item: the result of the
+// WithThresholdFunc callback when one is set, then the WithCategoryThresholds
+// entry for the item's existing Category, otherwise the static threshold.
+func (p *ItemProcessor) thresholdFor(item *models.Item) float64 {
+	if p.thresholdFunc != nil {
+		return p.thresholdFunc(item)
+	}
+	if threshold, ok := p.categoryThreshold(item); ok {
+		return threshold
+	}
+	return float64(p.threshold)
+}
+
+// categoryThreshold returns the WithCategoryThresholds entry for item's
+// Category, if there is one.
+func (p *ItemProcessor) categoryThreshold(item *models.Item) (float64, bool) {
+	if item.Category == "" {
+		return 0, false
+	}
+	threshold, ok := p.catThreshold[item.Category]
+	return float64(threshold), ok
+}
+
+// record adds a processed item to the accumulated statistics.
+func (p *ItemProcessor) record(item *models.Item, result ProcessResult) {
+	delta := Stats{
+		ProcessedCount: 1,
+		SumValue:       item.Value,
+		MinDuration:    result.Duration,
+		MaxDuration:    result.Duration,
+		TotalDuration:  result.Duration,
+	}
+	p.metrics.processed.Add(1)
+	if result.ExceededThreshold {
+		delta.ExceededThreshold = 1
+		p.metrics.exceeded.Add(1)
+	}
+	p.stats.Add(delta)
+}
+
+// Stats returns a snapshot of the statistics accumulated so far.
+func (p *ItemProcessor) Stats() Stats {
+	return p.stats.Snapshot()
+}
@@ -0,0 +1,176 @@
+// tests/sample_project2/itemprocessor/itemprocessor_test.go
+package itemprocessor
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sourcelens/sampleproject2/models"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWithThresholdFunc(t *testing.T) {
+	byName := func(item *models.Item) float64 {
+		if strings.HasPrefix(item.Name, "Widget") {
+			return 50
+		}
+		return 200
+	}
+	// The func also wins over category thresholds and the static threshold.
+	p := NewItemProcessor(100, WithSilent(), WithThresholdFunc(byName), WithCategoryThresholds(map[string]int{"premium": 10}))
+	tests := []struct {
+		item models.Item
+		want bool
+	}{
+		{models.Item{ItemID: 1, Name: "Gadget Alpha", Value: 150.75}, false},                  // 150.75 vs 200
+		{models.Item{ItemID: 2, Name: "Widget Beta", Value: 85.0}, true},                      // 85 vs 50
+		{models.Item{ItemID: 3, Name: "Thingamajig Gamma", Value: 210.5}, true},               // 210.5 vs 200
+		{models.Item{ItemID: 4, Name: "Widget Delta", Value: 45, Category: "premium"}, false}, // 45 vs 50
+	}
+	for _, tt := range tests {
+		item := tt.item
+		if got := p.Exceeds(&item); got != tt.want {
+			t.Errorf("Exceeds(%q, %v) = %v, want %v", item.Name, item.Value, got, tt.want)
+		}
+		result, err := p.ProcessItem(context.Background(), &item)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.ExceededThreshold != tt.want {
+			t.Errorf("ProcessItem(%q, %v).ExceededThreshold = %v, want %v", item.Name, item.Value, result.ExceededThreshold, tt.want)
+		}
+	}
+}
+
+func TestWithCategoryThresholds(t *testing.T) {
+	p := NewItemProcessor(100, WithSilent(), WithCategoryThresholds(map[string]int{"premium": 500, "budget": 50}))
+	tests := []struct {
+		category string
+		value    float64
+		want     bool
+	}{
+		{"premium", 150, false},  // below its category's 500
+		{"budget", 75, true},     // above its category's 50
+		{"clearance", 150, true}, // not in the map: default 100
+		{"clearance", 75, false},
+		{"", 150, true}, // no category: default 100
+	}
+	for _, tt := range tests {
+		item := &models.Item{ItemID: 1, Name: "Item", Value: tt.value, Category: tt.category}
+		result, err := p.ProcessItem(context.Background(), item)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.ExceededThreshold != tt.want {
+			t.Errorf("category %q value %v: ExceededThreshold = %v, want %v", tt.category, tt.value, result.ExceededThreshold, tt.want)
+		}
+	}
+}
+
+func TestWithCategoryThresholdsIsIdempotent(t *testing.T) {
+	p := NewItemProcessor(100, WithSilent(), WithCategoryThresholds(map[string]int{"budget": 50}))
+	item := &models.Item{ItemID: 1, Name: "Item", Value: 75, Category: "budget"}
+	for run := 1; run <= 2; run++ {
+		result, err := p.ProcessItem(context.Background(), item)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !result.ExceededThreshold || result.Category != CategoryHigh {
+			t.Errorf("run %d: result = %+v, want exceeded and %q", run, result, CategoryHigh)
+		}
+		if item.Category != "budget" {
+			t.Errorf("run %d: item Category = %q, want the business category kept", run, item.Category)
+		}
+	}
+}
+
+func BenchmarkProcessItem(b *testing.B) {
+	benchmarks := []struct {
+		name string
+		opts []Option
+	}{
+		// Default logging, formatted in full but written to io.Discard so the
+		// benchmark output stays readable.
+		{"default", []Option{WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil)))}},
+		{"silent", []Option{WithSilent()}},
+	}
+	ctx := context.Background()
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			p := NewItemProcessor(100, bm.opts...)
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				item := models.Item{ItemID: 1, Name: "Gadget Alpha", Value: 150.75}
+				if _, err := p.ProcessItem(ctx, &item); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestWithSilentAllocatesLess(t *testing.T) {
+	allocs := func(opts ...Option) float64 {
+		p := NewItemProcessor(100, opts...)
+		ctx := context.Background()
+		return testing.AllocsPerRun(100, func() {
+			item := models.Item{ItemID: 1, Name: "Gadget Alpha", Value: 150.75}
+			if _, err := p.ProcessItem(ctx, &item); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+	logged := allocs(WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))))
+	silent := allocs(WithSilent())
+	// What remains when silent is the item itself and its processed tag; the
+	// log record and its boxed attributes are never built.
+	if silent >= logged || silent > 2 {
+		t.Errorf("silent ProcessItem makes %v allocations, logged %v; want at most 2 and fewer than logged", silent, logged)
+	}
+}
+
+func TestWithRounding(t *testing.T) {
+	if _, err := WithRounding(-1); err == nil {
+		t.Error("WithRounding(-1) returned no error")
+	}
+	opt, err := WithRounding(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := NewItemProcessor(100, WithSilent(), opt)
+	item := &models.Item{ItemID: 1, Name: "Item", Value: 100.04}
+	result, err := p.ProcessItem(context.Background(), item)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Rounding happens before the comparison: 100.04 becomes 100.0, not above 100.
+	if item.Value != 100 || result.ExceededThreshold {
+		t.Errorf("Value = %v, ExceededThreshold = %v, want 100 and false", item.Value, result.ExceededThreshold)
+	}
+}
+
+func TestWithTimeoutRuleCannotTouchOriginalTags(t *testing.T) {
+	release := make(chan struct{})
+	finished := make(chan struct{})
+	p := NewItemProcessor(100, WithSilent(), WithTimeout(10*time.Millisecond), WithRules(Rule{
+		Name: "slow",
+		Func: func(item *models.Item) error {
+			defer close(finished)
+			<-release
+			item.Tags[0] = "changed"
+			return nil
+		},
+	}))
+	item := &models.Item{ItemID: 1, Name: "Item", Value: 150, Tags: []string{"original"}}
+	if _, err := p.ProcessItem(context.Background(), item); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("ProcessItem error = %v, want context.DeadlineExceeded", err)
+	}
+	close(release) // let the overrunning rule write to its copy
+	<-finished
+	if item.Tags[0] != "original" {
+		t.Errorf("timed-out rule changed the original tags to %v", item.Tags)
+	}
+}
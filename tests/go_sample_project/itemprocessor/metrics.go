@@ -0,0 +1,46 @@
+// tests/sample_project2/itemprocessor/metrics.go
+package itemprocessor
+
+import (
+	"expvar"
+	"fmt"
+)
+
+// Names under which PublishMetrics registers the processor's counters.
+const (
+	MetricItemsProcessed = "items_processed_total"
+	MetricItemsExceeded  = "items_exceeded_total"
+	MetricProcessErrors  = "process_errors_total"
+)
+
+// metrics holds the expvar counters updated by ProcessItem.
+// expvar.Int is safe for concurrent use.
+type metrics struct {
+	processed expvar.Int
+	exceeded  expvar.Int
+	errors    expvar.Int
+}
+
+// PublishMetrics registers the processor's counters with expvar so they are
+// served on /debug/vars. Counting starts at construction, so values published
+// later include earlier work. expvar names are process-global; publishing a
+// second processor, or the same one twice, returns an error.
+func (p *ItemProcessor) PublishMetrics() error {
+	vars := []struct {
+		name string
+		v    *expvar.Int
+	}{
+		{MetricItemsProcessed, &p.metrics.processed},
+		{MetricItemsExceeded, &p.metrics.exceeded},
+		{MetricProcessErrors, &p.metrics.errors},
+	}
+	for _, v := range vars {
+		if expvar.Get(v.name) != nil {
+			return fmt.Errorf("expvar %q is already published", v.name)
+		}
+	}
+	for _, v := range vars {
+		expvar.Publish(v.name, v.v)
+	}
+	return nil
+}
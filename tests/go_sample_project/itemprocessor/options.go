@@ -0,0 +1,177 @@
+// tests/sample_project2/itemprocessor/options.go
+package itemprocessor
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"sourcelens/sampleproject2/models"
+	"time"
+)
+
+// Option configures an ItemProcessor at construction time.
+type Option func(*ItemProcessor)
+
+// WithLogger sets the logger used by the processor. A nil logger means slog.Default().
+func WithLogger(logger *slog.Logger) Option {
+	return func(p *ItemProcessor) {
+		if logger == nil {
+			logger = slog.Default()
+		}
+		p.logger = logger
+	}
+}
+
+// WithSilent discards all of the processor's log output, so that benchmarks
+// measure the processing itself rather than formatting and writing logs.
+func WithSilent() Option {
+	return func(p *ItemProcessor) {
+		p.logger = slog.New(discardHandler{})
+	}
+}
+
+// discardHandler is a slog.Handler that is never enabled.
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (h discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return h }
+func (h discardHandler) WithGroup(string) slog.Handler           { return h }
+
+// WithComparisonOp sets the operator used to compare item values against the threshold.
+func WithComparisonOp(op ComparisonOp) Option {
+	return func(p *ItemProcessor) {
+		p.op = op
+	}
+}
+
+// WithRules registers rules to run in the given order, after any added by earlier options.
+func WithRules(rules ...Rule) Option {
+	return func(p *ItemProcessor) {
+		p.rules = append(p.rules, rules...)
+	}
+}
+
+// WithTimeout bounds how long the rules for a single item may run. When it
+// elapses ProcessItem returns a timeout error and leaves the item unchanged.
+// Zero or negative means no limit.
+func WithTimeout(d time.Duration) Option {
+	return func(p *ItemProcessor) {
+		p.timeout = d
+	}
+}
+
+// WithThresholdFunc computes the threshold per item instead of using the static
+// threshold passed to NewItemProcessor, e.g. a different cutoff per name prefix.
+// A nil function keeps the static threshold.
+func WithThresholdFunc(fn func(*models.Item) float64) Option {
+	return func(p *ItemProcessor) {
+		p.thresholdFunc = fn
+	}
+}
+
+// WithRateLimit caps processing at perSecond items per second across all
+// callers, so ProcessBatch and ProcessStream pace themselves regardless of the
+// number of workers. Items are spaced evenly, without bursts.
+// A zero or negative rate means unlimited.
+func WithRateLimit(perSecond float64) Option {
+	return func(p *ItemProcessor) {
+		p.limiter = newRateLimiter(perSecond)
+	}
+}
+
+// WithRounding makes ProcessItem round each item's Value to places decimal
+// places (half-to-even, see models.Round) after the rules run and before the
+// threshold comparison. Negative places are rejected here rather than
+// failing every item later.
+func WithRounding(places int) (Option, error) {
+	if places < 0 {
+		return nil, fmt.Errorf("invalid rounding precision %d: must not be negative", places)
+	}
+	return func(p *ItemProcessor) {
+		p.rounding = true
+		p.places = places
+	}, nil
+}
+
+// WithAuditLog writes an AuditRecord to w, as one JSON object per line, for
+// every item ProcessItem marks as processed. Write failures are logged and do
+// not fail the item. A nil writer disables auditing, which is the default.
+func WithAuditLog(w io.Writer) Option {
+	return func(p *ItemProcessor) {
+		if w == nil {
+			p.audit = nil
+			return
+		}
+		p.audit = &auditLog{w: w}
+	}
+}
+
+// WithCategoryThresholds sets per-category thresholds. An item that already
+// has a Category when it reaches ProcessItem is compared against that
+// category's threshold; items with no Category, or one missing from the map,
+// use the default threshold. WithThresholdFunc takes precedence when both are set.
+// An item whose Category is in the map keeps it rather than receiving
+// CategoryHigh, CategoryNormal or CategoryLow, so processing it again uses the
+// same threshold; ProcessResult.Category still reports the outcome.
+// The map is copied.
+func WithCategoryThresholds(thresholds map[string]int) Option {
+	return func(p *ItemProcessor) {
+		p.catThreshold = make(map[string]int, len(thresholds))
+		for category, threshold := range thresholds {
+			p.catThreshold[category] = threshold
+		}
+	}
+}
+
+// WithValidator makes ProcessItem check every item with validate before the
+// rules run. A non-nil error rejects the item: it is not marked as processed
+// and ProcessItem returns the error wrapped. Unlike load-time validation, the
+// check can close over processor settings such as the threshold.
+func WithValidator(validate func(*models.Item) error) Option {
+	return func(p *ItemProcessor) {
+		p.validator = validate
+	}
+}
+
+// WithCentsComparison makes the threshold comparison use integer cents:
+// the item value is converted with models.ValueCents and the threshold is
+// rounded the same way, so float drift such as 100.00000000000001 cannot
+// push a value across a 100.00 boundary.
+func WithCentsComparison() Option {
+	return func(p *ItemProcessor) {
+		p.cents = true
+	}
+}
+
+// WithBaseline compares every item with its value in a previous snapshot,
+// keyed by ItemID. ProcessItem stores the difference in Item.Delta and
+// ProcessResult.Delta and, when it exceeds percent of the previous value
+// (e.g. 10 for 10%), sets ProcessResult.Changed and tags the item
+// models.TagChanged. Items missing from the baseline are flagged as
+// ProcessResult.New and tagged models.TagNew instead. A negative percent
+// flags any change. The map is copied; a nil map disables the comparison.
+func WithBaseline(previous map[int]float64, percent float64) Option {
+	return func(p *ItemProcessor) {
+		if previous == nil {
+			p.baseline = nil
+			return
+		}
+		b := &baseline{values: make(map[int]float64, len(previous)), percent: max(percent, 0)}
+		for id, value := range previous {
+			b.values[id] = value
+		}
+		p.baseline = b
+	}
+}
+
+// WithSkipProcessed makes ProcessItem leave items that are already Processed
+// untouched, so reruns over the same data are idempotent. Skipped items are
+// flagged in ProcessResult.Skipped and counted in Stats.AlreadyProcessedCount
+// rather than TotalItems; rules, hooks and the audit log do not see them.
+func WithSkipProcessed() Option {
+	return func(p *ItemProcessor) {
+		p.skipProcessed = true
+	}
+}
@@ -0,0 +1,18 @@
+// tests/sample_project2/itemprocessor/passthrough.go
+package itemprocessor
+
+import "log/slog"
+
+// NewPassthrough is a constructor for an ItemProcessor that leaves items
+// untouched: no rules, threshold comparison, category, tags or processed flag
+// are applied. Every item is still counted as processed in Stats, so a
+// pipeline built with it simply loads and saves, e.g. to convert JSON to CSV.
+// Only the logging options (WithLogger, WithSilent) have an effect.
+func NewPassthrough(opts ...Option) *ItemProcessor {
+	p := &ItemProcessor{op: GreaterThan, passthrough: true, logger: slog.Default()}
+	for _, opt := range opts {
+		opt(p)
+	}
+	p.logger.Info("ItemProcessor initialized", "mode", "passthrough")
+	return p
+}
@@ -0,0 +1,32 @@
+// tests/sample_project2/itemprocessor/processor.go
+package itemprocessor
+
+import (
+	"context"
+	"sourcelens/sampleproject2/models"
+)
+
+// Processor is the behavior the pipeline requires to process items, so custom
+// implementations and test fakes can stand in for ItemProcessor.
+type Processor interface {
+	ProcessItem(ctx context.Context, item *models.Item) (ProcessResult, error)
+}
+
+// StatsReporter is implemented by processors that keep their own statistics.
+// Callers should type-assert for it; ItemProcessor implements it.
+type StatsReporter interface {
+	Stats() Stats
+}
+
+// ItemValidator is implemented by processors that can check an item without
+// processing it, e.g. for a preflight run. Callers should type-assert for it.
+type ItemValidator interface {
+	ValidateItem(item *models.Item) error
+}
+
+// Compile-time checks that ItemProcessor satisfies the processor interfaces.
+var (
+	_ Processor     = (*ItemProcessor)(nil)
+	_ StatsReporter = (*ItemProcessor)(nil)
+	_ ItemValidator = (*ItemProcessor)(nil)
+)
@@ -0,0 +1,51 @@
+// tests/sample_project2/itemprocessor/prometheus.go
+package itemprocessor
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// promMetric is one sample written by WritePrometheus.
+type promMetric struct {
+	name, kind, help string
+	value            float64
+}
+
+// WritePrometheus writes s to w in the Prometheus text exposition format,
+// each metric with its HELP and TYPE lines, e.g. for a node_exporter
+// textfile collector after a run. Metric names start with "sourcelens_";
+// counts are counters, durations are in seconds. Unlike PublishMetrics it
+// describes only these Stats, not the live processor.
+func (s Stats) WritePrometheus(w io.Writer) error {
+	dryRun := 0.0
+	if s.DryRun {
+		dryRun = 1
+	}
+	metrics := []promMetric{
+		{"sourcelens_items_total", "counter", "Items the processor started working on.", float64(s.TotalItems)},
+		{"sourcelens_items_processed_total", "counter", "Items successfully marked as processed.", float64(s.ProcessedCount)},
+		{"sourcelens_items_exceeded_threshold_total", "counter", "Processed items whose value exceeded the threshold.", float64(s.ExceededThreshold)},
+		{"sourcelens_items_saved_total", "counter", "Items written by the pipeline.", float64(s.SavedCount)},
+		{"sourcelens_items_skipped_total", "counter", "Items the pipeline filter excluded.", float64(s.SkippedCount)},
+		{"sourcelens_items_already_processed_total", "counter", "Items skipped because they were already processed.", float64(s.AlreadyProcessedCount)},
+		{"sourcelens_item_errors_total", "counter", "Items that failed to process.", float64(len(s.Errors))},
+		{"sourcelens_processed_value_sum", "gauge", "Sum of the values of processed items.", s.SumValue},
+		{"sourcelens_dry_run", "gauge", "1 if the save step was skipped, else 0.", dryRun},
+		{"sourcelens_rule_duration_seconds_total", "counter", "Rule execution time summed over processed items.", s.TotalDuration.Seconds()},
+		{"sourcelens_rule_duration_seconds_min", "gauge", "Shortest rule execution time of a processed item.", s.MinDuration.Seconds()},
+		{"sourcelens_rule_duration_seconds_max", "gauge", "Longest rule execution time of a processed item.", s.MaxDuration.Seconds()},
+	}
+
+	bw := bufio.NewWriter(w)
+	for _, m := range metrics {
+		fmt.Fprintf(bw, "# HELP %s %s\n# TYPE %s %s\n%s %s\n",
+			m.name, m.help, m.name, m.kind, m.name, strconv.FormatFloat(m.value, 'g', -1, 64))
+	}
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("failed to write Prometheus metrics: %w", err)
+	}
+	return nil
+}
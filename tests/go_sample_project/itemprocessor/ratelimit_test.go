@@ -0,0 +1,66 @@
+// tests/sample_project2/itemprocessor/ratelimit_test.go
+package itemprocessor
+
+import (
+	"context"
+	"errors"
+	"sourcelens/sampleproject2/models"
+	"testing"
+	"time"
+)
+
+func rateLimitItems(n int) []*models.Item {
+	items := make([]*models.Item, n)
+	for i := range items {
+		items[i] = &models.Item{ItemID: i + 1, Name: "Item", Value: float64(i)}
+	}
+	return items
+}
+
+func TestWithRateLimitPacesBatch(t *testing.T) {
+	const n, perSecond = 11, 100
+	p := NewItemProcessor(100, WithSilent(), WithRateLimit(perSecond))
+
+	start := time.Now()
+	if err := p.ProcessBatch(context.Background(), rateLimitItems(n), 4); err != nil {
+		t.Fatal(err)
+	}
+	elapsed := time.Since(start)
+
+	// The first item goes immediately and each later one waits one interval,
+	// however many workers there are.
+	want := time.Duration(n-1) * time.Second / perSecond
+	if elapsed < want {
+		t.Errorf("%d items at %d/s took %v, want at least %v", n, perSecond, elapsed, want)
+	}
+	if elapsed > want+time.Second {
+		t.Errorf("%d items at %d/s took %v, want about %v", n, perSecond, elapsed, want)
+	}
+}
+
+func TestWithRateLimitNonPositiveIsUnlimited(t *testing.T) {
+	for _, perSecond := range []float64{0, -1} {
+		p := NewItemProcessor(100, WithSilent(), WithRateLimit(perSecond))
+		start := time.Now()
+		if err := p.ProcessBatch(context.Background(), rateLimitItems(1000), 4); err != nil {
+			t.Fatal(err)
+		}
+		if elapsed := time.Since(start); elapsed > time.Second {
+			t.Errorf("rate %v: 1000 items took %v, want no throttling", perSecond, elapsed)
+		}
+	}
+}
+
+func TestWithRateLimitCanceled(t *testing.T) {
+	p := NewItemProcessor(100, WithSilent(), WithRateLimit(1))
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	// The second item would wait a full second; cancellation must cut that short.
+	start := time.Now()
+	if err := p.ProcessBatch(ctx, rateLimitItems(2), 1); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("ProcessBatch error = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("canceled batch took %v, want it to stop at the deadline", elapsed)
+	}
+}
@@ -0,0 +1,89 @@
+// tests/sample_project2/itemprocessor/stats.go
+package itemprocessor
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Stats summarizes the work done by an ItemProcessor.
+type Stats struct {
+	TotalItems            int           // Items the processor started working on.
+	ProcessedCount        int           // Items successfully marked as processed.
+	ExceededThreshold     int           // Processed items whose value exceeded the threshold.
+	SumValue              float64       // Sum of the values of processed items.
+	SavedCount            int           // Items written by the pipeline, or that would have been in a dry run.
+	SkippedCount          int           // Items the pipeline left untouched because its filter excluded them.
+	AlreadyProcessedCount int           // Items skipped because they were already processed (see WithSkipProcessed).
+	DryRun                bool          // True when the save step was skipped.
+	Errors                []error       // Per-item failures collected when processing continues on error.
+	MissingIDs            []int         // IDs requested with the pipeline's WithIDFilter that were not in the data.
+	MinDuration           time.Duration // Shortest rule execution time of a processed item (see ProcessResult.Duration).
+	MaxDuration           time.Duration // Longest rule execution time of a processed item.
+	TotalDuration         time.Duration // Rule execution time summed over processed items.
+}
+
+// String provides a one-line summary suitable for logs.
+func (s Stats) String() string {
+	return fmt.Sprintf("Stats(Total=%d, Processed=%d, ExceededThreshold=%d, SumValue=%.2f, Saved=%d, Skipped=%d, AlreadyProcessed=%d, DryRun=%t, Errors=%d, AvgDuration=%s)",
+		s.TotalItems, s.ProcessedCount, s.ExceededThreshold, s.SumValue, s.SavedCount, s.SkippedCount, s.AlreadyProcessedCount, s.DryRun, len(s.Errors), s.AvgDuration())
+}
+
+// AvgDuration returns the mean rule execution time per processed item, or 0 if none were processed.
+func (s Stats) AvgDuration() time.Duration {
+	if s.ProcessedCount == 0 {
+		return 0
+	}
+	return s.TotalDuration / time.Duration(s.ProcessedCount)
+}
+
+// Add accumulates other into s: counts, SumValue and TotalDuration are summed,
+// Min/MaxDuration are widened, Errors and MissingIDs are appended and DryRun is set if either is. It is not safe for concurrent use;
+// share a StatsCollector between goroutines instead.
+func (s *Stats) Add(other Stats) {
+	if other.ProcessedCount > 0 {
+		if s.ProcessedCount == 0 || other.MinDuration < s.MinDuration {
+			s.MinDuration = other.MinDuration
+		}
+		if other.MaxDuration > s.MaxDuration {
+			s.MaxDuration = other.MaxDuration
+		}
+	}
+	s.TotalDuration += other.TotalDuration
+	s.TotalItems += other.TotalItems
+	s.ProcessedCount += other.ProcessedCount
+	s.ExceededThreshold += other.ExceededThreshold
+	s.SumValue += other.SumValue
+	s.SavedCount += other.SavedCount
+	s.SkippedCount += other.SkippedCount
+	s.AlreadyProcessedCount += other.AlreadyProcessedCount
+	s.DryRun = s.DryRun || other.DryRun
+	s.Errors = append(s.Errors, other.Errors...)
+	s.MissingIDs = append(s.MissingIDs, other.MissingIDs...)
+}
+
+// StatsCollector accumulates Stats from concurrent workers. The zero value is
+// ready to use and must not be copied after first use.
+type StatsCollector struct {
+	mu    sync.Mutex
+	stats Stats
+}
+
+// Add merges delta into the collected totals. It is safe for concurrent use.
+func (c *StatsCollector) Add(delta Stats) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.stats.Add(delta)
+}
+
+// Snapshot returns a copy of the totals collected so far, including its own
+// copies of Errors and MissingIDs.
+func (c *StatsCollector) Snapshot() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	snapshot := c.stats
+	snapshot.Errors = append([]error(nil), c.stats.Errors...)
+	snapshot.MissingIDs = append([]int(nil), c.stats.MissingIDs...)
+	return snapshot
+}
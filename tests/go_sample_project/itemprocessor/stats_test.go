@@ -0,0 +1,84 @@
+// tests/sample_project2/itemprocessor/stats_test.go
+package itemprocessor
+
+import (
+	"context"
+	"sourcelens/sampleproject2/models"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestStatsCollectorConcurrentAdd(t *testing.T) {
+	const goroutines, perGoroutine = 50, 200
+	var c StatsCollector
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perGoroutine; i++ {
+				c.Add(Stats{
+					TotalItems:        1,
+					ProcessedCount:    1,
+					ExceededThreshold: i % 2,
+					SumValue:          1.5,
+					MinDuration:       time.Millisecond,
+					MaxDuration:       time.Millisecond,
+					TotalDuration:     time.Millisecond,
+				})
+			}
+		}()
+	}
+	wg.Wait()
+
+	const total = goroutines * perGoroutine
+	got := c.Snapshot()
+	if got.TotalItems != total || got.ProcessedCount != total {
+		t.Errorf("TotalItems, ProcessedCount = %d, %d, want %d each", got.TotalItems, got.ProcessedCount, total)
+	}
+	if want := total / 2; got.ExceededThreshold != want {
+		t.Errorf("ExceededThreshold = %d, want %d", got.ExceededThreshold, want)
+	}
+	if want := 1.5 * total; got.SumValue != want {
+		t.Errorf("SumValue = %v, want %v", got.SumValue, want)
+	}
+	if want := total * time.Millisecond; got.TotalDuration != want {
+		t.Errorf("TotalDuration = %v, want %v", got.TotalDuration, want)
+	}
+}
+
+func TestProcessItemConcurrentStats(t *testing.T) {
+	const n = 500
+	p := NewItemProcessor(100, WithSilent())
+	items := make([]models.Item, n)
+	for i := range items {
+		items[i] = models.Item{ItemID: i + 1, Name: "Item", Value: float64(i % 200)}
+	}
+	var wg sync.WaitGroup
+	for i := range items {
+		wg.Add(1)
+		go func(item *models.Item) {
+			defer wg.Done()
+			if _, err := p.ProcessItem(context.Background(), item); err != nil {
+				t.Error(err)
+			}
+		}(&items[i])
+	}
+	wg.Wait()
+
+	got := p.Stats()
+	if got.TotalItems != n || got.ProcessedCount != n {
+		t.Errorf("TotalItems, ProcessedCount = %d, %d, want %d each", got.TotalItems, got.ProcessedCount, n)
+	}
+	// The default comparison is value > threshold.
+	want := 0
+	for _, item := range items {
+		if item.Value > 100 {
+			want++
+		}
+	}
+	if got.ExceededThreshold != want {
+		t.Errorf("ExceededThreshold = %d, want %d", got.ExceededThreshold, want)
+	}
+}
@@ -0,0 +1,53 @@
+// tests/sample_project2/itemprocessor/template.go
+package itemprocessor
+
+import (
+	"fmt"
+	"sourcelens/sampleproject2/models"
+	"strings"
+	"text/template"
+)
+
+// MessageData is the data a WithTemplate template is executed with. The item
+// fields are promoted, so a template can use {{.Name}} or {{.Value}}.
+type MessageData struct {
+	models.Item
+	Threshold float64
+	Op        string // The comparison operator, e.g. ">".
+	Exceeded  bool   // Whether the value satisfied the comparison.
+	Message   string // The default message, e.g. "Item value exceeds threshold".
+}
+
+// WithTemplate renders the message of the per-item comparison log line from
+// tmpl, a text/template executed with MessageData, e.g.
+// `{{.Name}} ({{.Value}}) {{if .Exceeded}}over{{else}}under{{end}} {{.Threshold}}`.
+// The structured attributes are logged as before. Without it the message is
+// {{.Message}}. The template is parsed here, so syntax errors are reported
+// now rather than per item; an execution failure for a particular item, such
+// as an unknown field or an out-of-range index, falls back to the default
+// message.
+func WithTemplate(tmpl string) (Option, error) {
+	t, err := template.New("item").Parse(tmpl)
+	if err != nil {
+		return nil, fmt.Errorf("invalid item template: %w", err)
+	}
+	return func(p *ItemProcessor) {
+		p.template = t
+	}, nil
+}
+
+// message returns the log message for item's comparison outcome, rendered
+// from the WithTemplate template when one is set.
+func (p *ItemProcessor) message(item *models.Item, threshold float64, matched bool) string {
+	msg := p.op.describe(matched)
+	if p.template == nil {
+		return msg
+	}
+	var b strings.Builder
+	data := MessageData{Item: *item, Threshold: threshold, Op: p.op.String(), Exceeded: matched, Message: msg}
+	if err := p.template.Execute(&b, data); err != nil {
+		p.logger.Warn("Item template failed, using default message", "item_id", item.ItemID, "error", err)
+		return msg
+	}
+	return b.String()
+}
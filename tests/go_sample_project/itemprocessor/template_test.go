@@ -0,0 +1,45 @@
+// tests/sample_project2/itemprocessor/template_test.go
+package itemprocessor
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"sourcelens/sampleproject2/models"
+	"strings"
+	"testing"
+)
+
+func TestWithTemplateRejectsSyntaxErrors(t *testing.T) {
+	if _, err := WithTemplate("{{.Name"); err == nil {
+		t.Error("WithTemplate accepted an unterminated action")
+	}
+}
+
+func TestWithTemplateRendersPerItem(t *testing.T) {
+	// index fails on an empty MessageData, so this must not be rejected up front.
+	opt, err := WithTemplate(`{{.Name}} first tag {{index .Tags 0}}`)
+	if err != nil {
+		t.Fatalf("WithTemplate: %v", err)
+	}
+	var buf bytes.Buffer
+	p := NewItemProcessor(100, opt, WithLogger(slog.New(slog.NewTextHandler(&buf, nil))))
+
+	tagged := &models.Item{ItemID: 1, Name: "Gadget Alpha", Value: 150.75, Tags: []string{"red"}}
+	if _, err := p.ProcessItem(context.Background(), tagged); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), `msg="Gadget Alpha first tag red"`) {
+		t.Errorf("log output %q does not contain the rendered message", buf.String())
+	}
+
+	buf.Reset()
+	untagged := &models.Item{ItemID: 2, Name: "Widget Beta", Value: 85.0}
+	if _, err := p.ProcessItem(context.Background(), untagged); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "Item template failed, using default message") ||
+		!strings.Contains(buf.String(), `msg="`+p.op.describe(false)+`"`) {
+		t.Errorf("log output %q does not show the fallback to the default message", buf.String())
+	}
+}
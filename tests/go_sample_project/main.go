@@ -2,60 +2,155 @@
 package main
 
 import (
-	"log"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/signal"
 	"sourcelens/sampleproject2/config"
 	"sourcelens/sampleproject2/datahandler"
 	"sourcelens/sampleproject2/itemprocessor"
+	"sourcelens/sampleproject2/pipeline"
+	"sourcelens/sampleproject2/report"
+	"syscall"
 )
 
-// runProcessingPipeline executes the main data processing logic.
-func runProcessingPipeline() {
-	log.Println("Starting Sample Project 2 processing pipeline...")
+// exitInterrupted is the conventional exit status for a process stopped by SIGINT (128 + 2).
+const exitInterrupted = 130
 
-	// 1. Initialize components using configuration
-	dataPath := config.GetDataPath()
-	threshold := config.GetThreshold()
+// pipelineOptions controls optional pipeline behavior.
+type pipelineOptions struct {
+	// Config supplies the threshold and other settings. Nil means config.Effective().
+	Config *config.Config
+	// DryRun loads and processes items normally but logs the items instead of saving them.
+	DryRun bool
+	// ContinueOnError keeps processing after an item fails and reports the failures in
+	// Stats.Errors. When false the first failure aborts the pipeline before saving.
+	ContinueOnError bool
+	// Prioritize processes items in descending Value order.
+	Prioritize bool
+	// Checkpoint names a sidecar file used to resume an interrupted run. Empty disables it.
+	Checkpoint string
+	// RequireItems fails the run when fewer items than this are loaded.
+	RequireItems int
+	// SkipProcessed leaves items that are already processed untouched.
+	SkipProcessed bool
+}
+
+// runProcessingPipeline validates the configuration, builds an ItemProcessor from it
+// and delegates to pipeline.Pipeline. The store is injected so the pipeline is
+// independent of the storage backend. Failures are returned rather than exiting,
+// so callers decide how to react.
+func runProcessingPipeline(ctx context.Context, store datahandler.DataStore, opts pipelineOptions) (itemprocessor.Stats, error) {
+	slog.Info("Starting Sample Project 2 processing pipeline")
 
-	dh := datahandler.NewDataHandler(dataPath)
-	ip := itemprocessor.NewItemProcessor(threshold)
+	cfg := opts.Config
+	if cfg == nil {
+		cfg = config.Effective()
+	}
+	if err := cfg.Validate(); err != nil {
+		return itemprocessor.Stats{}, fmt.Errorf("invalid configuration: %w", err)
+	}
 
-	// 2. Load data
-	itemsToProcess, err := dh.LoadItems()
+	// Initialize components using configuration and run the pipeline
+	var procOpts []itemprocessor.Option
+	if opts.SkipProcessed {
+		procOpts = append(procOpts, itemprocessor.WithSkipProcessed())
+	}
+	ip := itemprocessor.NewItemProcessor(cfg.Threshold, procOpts...)
+	stats, err := pipeline.New(store, ip).
+		WithDryRun(opts.DryRun).
+		WithContinueOnError(opts.ContinueOnError).
+		WithPrioritize(opts.Prioritize).
+		WithCheckpoint(opts.Checkpoint).
+		WithRequireItems(opts.RequireItems).
+		Run(ctx)
 	if err != nil {
-		log.Fatalf("Failed to load items: %v", err)
+		return stats, err
 	}
 
-	if len(itemsToProcess) == 0 {
-		log.Println("No items loaded. Exiting pipeline.")
+	slog.Info("Sample Project 2 processing pipeline finished")
+	return stats, nil
+}
+
+// openLogOutput opens path for appending log output, creating it if needed.
+// An empty path means stderr, which is not closed.
+func openLogOutput(path string) (io.WriteCloser, error) {
+	if path == "" {
+		return nopCloser{os.Stderr}, nil
+	}
+	return os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+}
+
+// nopCloser adds a no-op Close to a writer that must stay open.
+type nopCloser struct{ io.Writer }
+
+func (nopCloser) Close() error { return nil }
+
+func main() {
+	cli, err := parseFlags(os.Args[0], os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
 		return
 	}
-	log.Printf("Successfully loaded %d items.", len(itemsToProcess))
+	if err != nil {
+		os.Exit(2)
+	}
+	cfg, err := config.Resolve(cli.ConfigFlags)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to resolve configuration: %v\n", err)
+		os.Exit(2)
+	}
+
+	// Route all package logging (including the standard log package) through slog at the configured level.
+	logOut, err := openLogOutput(cfg.LogFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to open log file: %v\n", err)
+		os.Exit(2)
+	}
+	defer logOut.Close()
+	handler := slog.NewTextHandler(logOut, &slog.HandlerOptions{Level: cfg.SlogLevel()})
+	slog.SetDefault(slog.New(handler))
 
-	// 3. Process data items
-	for i := range itemsToProcess {
-		item := &itemsToProcess[i] // Get a pointer to the item in the slice
-		log.Printf("Passing item to processor: %s", item.String())
-		_, err := ip.ProcessItem(item)
-		if err != nil {
-			log.Printf("Failed to process item %d: %v", item.ItemID, err)
+	dh := datahandler.NewDataHandler(cfg.DataPath)
+	if cli.Mode == modeStats {
+		if err := printItemStats(os.Stdout, dh, cfg); err != nil {
+			slog.Error("Failed to compute item statistics", "error", err)
+			os.Exit(1)
 		}
+		return
 	}
+	// Cancel the pipeline on Ctrl-C or SIGTERM so it can stop between items and flush what it has done.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	// 4. Save processed data
-	saveSuccess, err := dh.SaveItems(itemsToProcess)
+	stats, err := runProcessingPipeline(ctx, dh, pipelineOptions{
+		Config:          cfg,
+		DryRun:          cli.DryRun,
+		ContinueOnError: cli.ContinueOnError,
+		Prioritize:      cli.Prioritize,
+		Checkpoint:      cli.Checkpoint,
+		RequireItems:    cli.RequireItems,
+		SkipProcessed:   cli.SkipProcessed,
+	})
 	if err != nil {
-		log.Fatalf("Error during save operation: %v", err)
+		interrupted := ctx.Err() != nil // read before stop, which cancels ctx
+		stop()
+		var saveErr *pipeline.SaveError
+		if interrupted && errors.Is(err, context.Canceled) && !errors.As(err, &saveErr) {
+			slog.Warn("Pipeline interrupted", "error", err, "stats", stats)
+			os.Exit(exitInterrupted)
+		}
+		slog.Error("Pipeline failed", "error", err, "stats", stats)
+		os.Exit(1)
 	}
-	if saveSuccess {
-		log.Println("Processed items saved successfully.")
-	} else {
-		log.Println("Failed to save processed items.")
+	slog.Info("Pipeline summary", "saved", stats.SavedCount, "stats", stats)
+	if cli.Report != "" {
+		if err := report.Write(os.Stdout, stats, cli.Report); err != nil {
+			slog.Error("Failed to write report", "error", err)
+			os.Exit(1)
+		}
 	}
-
-	log.Println("Sample Project 2 processing pipeline finished.")
 }
-
-func main() {
-	// In a real app, you would configure the logger here based on config.GetLogLevel()
-	runProcessingPipeline()
-}
\ No newline at end of file
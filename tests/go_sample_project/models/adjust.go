@@ -0,0 +1,17 @@
+// tests/sample_project2/models/adjust.go
+package models
+
+import "fmt"
+
+// ApplyFactor multiplies the Value of every item in place by factor,
+// e.g. 0.9 for a 10% discount. The factor must be positive and finite;
+// otherwise an error is returned and no item is modified.
+func ApplyFactor(items []Item, factor float64) error {
+	if !isFinite(factor) || factor <= 0 {
+		return fmt.Errorf("invalid adjustment factor %v: must be a positive finite number", factor)
+	}
+	for i := range items {
+		items[i].Value *= factor
+	}
+	return nil
+}
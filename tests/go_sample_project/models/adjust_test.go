@@ -0,0 +1,43 @@
+// tests/sample_project2/models/adjust_test.go
+package models
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+// sampleItems mirrors data/items.json.
+func sampleItems() []Item {
+	return []Item{
+		{ItemID: 1, Name: "Gadget Alpha", Value: 150.75},
+		{ItemID: 2, Name: "Widget Beta", Value: 85.0},
+		{ItemID: 3, Name: "Thingamajig Gamma", Value: 210.5},
+		{ItemID: 4, Name: "Doohickey Delta", Value: 55.2},
+	}
+}
+
+func TestApplyFactor(t *testing.T) {
+	items := sampleItems()
+	if err := ApplyFactor(items, 0.9); err != nil {
+		t.Fatal(err)
+	}
+	want := []float64{135.675, 76.5, 189.45, 49.68}
+	for i, item := range items {
+		if math.Abs(item.Value-want[i]) > 1e-9 {
+			t.Errorf("item %d Value = %v, want %v", item.ItemID, item.Value, want[i])
+		}
+	}
+}
+
+func TestApplyFactorRejectsInvalidFactors(t *testing.T) {
+	for _, factor := range []float64{0, -0.9, math.NaN(), math.Inf(1)} {
+		items := sampleItems()
+		if err := ApplyFactor(items, factor); err == nil {
+			t.Errorf("ApplyFactor(%v) returned no error", factor)
+		}
+		if !reflect.DeepEqual(items, sampleItems()) {
+			t.Errorf("ApplyFactor(%v) modified items: %+v", factor, items)
+		}
+	}
+}
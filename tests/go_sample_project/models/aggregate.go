@@ -0,0 +1,63 @@
+// tests/sample_project2/models/aggregate.go
+package models
+
+import (
+	"errors"
+	"math"
+)
+
+// isFinite reports whether v is neither NaN nor ±Inf.
+func isFinite(v float64) bool {
+	return !math.IsNaN(v) && !math.IsInf(v, 0)
+}
+
+// SumValues returns the sum of item values.
+// NaN and ±Inf values are skipped so a single bad row cannot poison the total.
+func SumValues(items []Item) float64 {
+	var sum float64
+	for _, item := range items {
+		if isFinite(item.Value) {
+			sum += item.Value
+		}
+	}
+	return sum
+}
+
+// AverageValue returns the mean of item values, skipping NaN and ±Inf like SumValues.
+// It returns an error if there are no finite values to average.
+func AverageValue(items []Item) (float64, error) {
+	var sum float64
+	count := 0
+	for _, item := range items {
+		if isFinite(item.Value) {
+			sum += item.Value
+			count++
+		}
+	}
+	if count == 0 {
+		return 0, errors.New("cannot average values: no items with a finite value")
+	}
+	return sum / float64(count), nil
+}
+
+// ValueRange returns the smallest and largest finite item values.
+// NaN and ±Inf values are skipped; it returns an error if no finite values remain.
+func ValueRange(items []Item) (minValue, maxValue float64, err error) {
+	found := false
+	for _, item := range items {
+		if !isFinite(item.Value) {
+			continue
+		}
+		if !found || item.Value < minValue {
+			minValue = item.Value
+		}
+		if !found || item.Value > maxValue {
+			maxValue = item.Value
+		}
+		found = true
+	}
+	if !found {
+		return 0, 0, errors.New("cannot compute value range: no items with a finite value")
+	}
+	return minValue, maxValue, nil
+}
@@ -0,0 +1,15 @@
+// tests/sample_project2/models/cents.go
+package models
+
+import "math"
+
+// ValueCents returns item.Value as a whole number of cents, computed as
+// math.Round(Value*100). Half-cent values round away from zero, so 0.125
+// gives 13 and -0.125 gives -13. The rounding sees the binary product, so a
+// value whose decimal form is a half cent but whose float is slightly below it
+// (1.005 is stored as 1.00499999...) rounds down; use Round first if exact
+// decimal ties matter. NaN and ±Inf have no cents value; the result for them
+// is unspecified.
+func ValueCents(item Item) int64 {
+	return int64(math.Round(item.Value * 100))
+}
@@ -0,0 +1,25 @@
+// tests/sample_project2/models/clone.go
+package models
+
+// Clone returns an independent copy of the item. Tags are copied, so changing
+// the clone's tags cannot affect the original.
+func (i *Item) Clone() Item {
+	clone := *i
+	if i.Tags != nil {
+		clone.Tags = append(make([]string, 0, len(i.Tags)), i.Tags...)
+	}
+	return clone
+}
+
+// CloneAll returns a deep copy of items, cloning each element.
+// A nil slice yields nil and an empty slice yields an empty slice.
+func CloneAll(items []Item) []Item {
+	if items == nil {
+		return nil
+	}
+	clones := make([]Item, len(items))
+	for idx, item := range items {
+		clones[idx] = item.Clone()
+	}
+	return clones
+}
@@ -0,0 +1,43 @@
+// tests/sample_project2/models/clone_test.go
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCloneIsIndependent(t *testing.T) {
+	original := Item{ItemID: 1, Name: "Gadget Alpha", Value: 150.75, Category: "over", Tags: []string{"red", "new"}}
+	want := Item{ItemID: 1, Name: "Gadget Alpha", Value: 150.75, Category: "over", Tags: []string{"red", "new"}}
+
+	clone := original.Clone()
+	if !reflect.DeepEqual(clone, original) {
+		t.Fatalf("Clone = %+v, want %+v", clone, original)
+	}
+	clone.Name = "Changed"
+	clone.Value = 1
+	clone.Tags[0] = "blue"
+	clone.AddTag("extra")
+	clone.MarkAsProcessed()
+
+	if !reflect.DeepEqual(original, want) {
+		t.Errorf("mutating the clone changed the original to %+v, want %+v", original, want)
+	}
+}
+
+func TestCloneAll(t *testing.T) {
+	if got := CloneAll(nil); got != nil {
+		t.Errorf("CloneAll(nil) = %v, want nil", got)
+	}
+	if got := CloneAll([]Item{}); got == nil || len(got) != 0 {
+		t.Errorf("CloneAll(empty) = %#v, want an empty non-nil slice", got)
+	}
+
+	items := []Item{{ItemID: 1, Tags: []string{"red"}}, {ItemID: 2}}
+	clones := CloneAll(items)
+	clones[0].Tags[0] = "blue"
+	clones[1].Name = "Changed"
+	if items[0].Tags[0] != "red" || items[1].Name != "" {
+		t.Errorf("mutating the clones changed the originals to %+v", items)
+	}
+}
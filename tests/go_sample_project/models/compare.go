@@ -0,0 +1,73 @@
+// tests/sample_project2/models/compare.go
+package models
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"time"
+)
+
+// ValueEpsilon is the tolerance used when comparing Value fields, so that
+// floating-point rounding noise is not reported as a change.
+const ValueEpsilon = 1e-9
+
+// valuesEqual reports whether a and b are equal within ValueEpsilon.
+// Two NaN values are considered equal.
+func valuesEqual(a, b float64) bool {
+	if math.IsNaN(a) || math.IsNaN(b) {
+		return math.IsNaN(a) && math.IsNaN(b)
+	}
+	return a == b || math.Abs(a-b) <= ValueEpsilon
+}
+
+// Equal reports whether a and b have the same field values, comparing Value within ValueEpsilon.
+func Equal(a, b Item) bool {
+	return len(Diff(a, b)) == 0
+}
+
+// Diff lists the fields that differ between a and b, one entry per field in
+// the form "Field: old -> new". It returns nil when the items are equal.
+// Tags are compared in order; nil and empty Tags are equal.
+func Diff(a, b Item) []string {
+	var diffs []string
+	if a.ItemID != b.ItemID {
+		diffs = append(diffs, fmt.Sprintf("ItemID: %d -> %d", a.ItemID, b.ItemID))
+	}
+	if a.Name != b.Name {
+		diffs = append(diffs, fmt.Sprintf("Name: %q -> %q", a.Name, b.Name))
+	}
+	if !valuesEqual(a.Value, b.Value) {
+		diffs = append(diffs, fmt.Sprintf("Value: %.2f -> %.2f", a.Value, b.Value))
+	}
+	if a.Processed != b.Processed {
+		diffs = append(diffs, fmt.Sprintf("Processed: %t -> %t", a.Processed, b.Processed))
+	}
+	if a.Category != b.Category {
+		diffs = append(diffs, fmt.Sprintf("Category: %q -> %q", a.Category, b.Category))
+	}
+	if !a.ProcessedAt.Equal(b.ProcessedAt) {
+		diffs = append(diffs, fmt.Sprintf("ProcessedAt: %s -> %s", formatTime(a.ProcessedAt), formatTime(b.ProcessedAt)))
+	}
+	if !slices.Equal(a.Tags, b.Tags) {
+		diffs = append(diffs, fmt.Sprintf("Tags: %v -> %v", a.Tags, b.Tags))
+	}
+	if a.ProcessedReason != b.ProcessedReason {
+		diffs = append(diffs, fmt.Sprintf("ProcessedReason: %q -> %q", a.ProcessedReason, b.ProcessedReason))
+	}
+	if !valuesEqual(a.NormalizedValue, b.NormalizedValue) {
+		diffs = append(diffs, fmt.Sprintf("NormalizedValue: %.4f -> %.4f", a.NormalizedValue, b.NormalizedValue))
+	}
+	if !valuesEqual(a.Delta, b.Delta) {
+		diffs = append(diffs, fmt.Sprintf("Delta: %.2f -> %.2f", a.Delta, b.Delta))
+	}
+	return diffs
+}
+
+// formatTime renders t for diffs, showing the zero time as "unset".
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "unset"
+	}
+	return t.Format(time.RFC3339Nano)
+}
@@ -0,0 +1,31 @@
+// tests/sample_project2/models/filter.go
+package models
+
+import "fmt"
+
+// FilterByValue returns the items whose Value lies in the inclusive range [minValue, maxValue].
+// The input slice is not modified. It returns an error if minValue > maxValue.
+func FilterByValue(items []Item, minValue, maxValue float64) ([]Item, error) {
+	if minValue > maxValue {
+		return nil, fmt.Errorf("invalid value range: min %.2f is greater than max %.2f", minValue, maxValue)
+	}
+	filtered := make([]Item, 0, len(items))
+	for _, item := range items {
+		if item.Value >= minValue && item.Value <= maxValue {
+			filtered = append(filtered, item)
+		}
+	}
+	return filtered, nil
+}
+
+// FilterProcessed returns the items whose Processed flag is set.
+// The result is never nil, so it encodes as an empty JSON array rather than null.
+func FilterProcessed(items []Item) []Item {
+	processed := make([]Item, 0, len(items))
+	for _, item := range items {
+		if item.Processed {
+			processed = append(processed, item)
+		}
+	}
+	return processed
+}
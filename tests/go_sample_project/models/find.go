@@ -0,0 +1,22 @@
+// tests/sample_project2/models/find.go
+package models
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrItemNotFound is wrapped by the errors returned when no item has the
+// requested ItemID, so callers can detect it with errors.Is.
+var ErrItemNotFound = errors.New("item not found")
+
+// FindByID returns the first item with the given ItemID, or an error wrapping
+// ErrItemNotFound if there is none.
+func FindByID(items []Item, id int) (Item, error) {
+	for _, item := range items {
+		if item.ItemID == id {
+			return item, nil
+		}
+	}
+	return Item{}, fmt.Errorf("%w: ItemID %d", ErrItemNotFound, id)
+}
@@ -0,0 +1,19 @@
+// tests/sample_project2/models/group.go
+package models
+
+// Uncategorized is the GroupByCategory key for items with an empty Category.
+const Uncategorized = "uncategorized"
+
+// GroupByCategory buckets items by their Category field, preserving input order
+// within each bucket. Items without a category are grouped under Uncategorized.
+func GroupByCategory(items []Item) map[string][]Item {
+	groups := make(map[string][]Item)
+	for _, item := range items {
+		key := item.Category
+		if key == "" {
+			key = Uncategorized
+		}
+		groups[key] = append(groups[key], item)
+	}
+	return groups
+}
@@ -0,0 +1,52 @@
+// tests/sample_project2/models/histogram.go
+package models
+
+import "fmt"
+
+// Bucket is one bin of a Histogram: the values in [Min, Max), or [Min, Max]
+// for the last bin.
+type Bucket struct {
+	Min   float64
+	Max   float64
+	Count int
+}
+
+// Histogram splits the range of finite item values into buckets bins of equal
+// width and counts the items in each, in ascending order. NaN and ±Inf values
+// are skipped. When every finite value is the same there is no range to split,
+// so a single bucket with Min == Max holds all of them. It returns an error if
+// buckets is not positive or no item has a finite value.
+func Histogram(items []Item, buckets int) ([]Bucket, error) {
+	if buckets <= 0 {
+		return nil, fmt.Errorf("invalid bucket count %d: must be positive", buckets)
+	}
+	minValue, maxValue, err := ValueRange(items)
+	if err != nil {
+		return nil, err
+	}
+	if minValue == maxValue {
+		count := 0
+		for _, item := range items {
+			if isFinite(item.Value) {
+				count++
+			}
+		}
+		return []Bucket{{Min: minValue, Max: maxValue, Count: count}}, nil
+	}
+
+	width := (maxValue - minValue) / float64(buckets)
+	hist := make([]Bucket, buckets)
+	for i := range hist {
+		hist[i].Min = minValue + float64(i)*width
+		hist[i].Max = minValue + float64(i+1)*width
+	}
+	hist[buckets-1].Max = maxValue
+	for _, item := range items {
+		if !isFinite(item.Value) {
+			continue
+		}
+		idx := min(int((item.Value-minValue)/width), buckets-1)
+		hist[idx].Count++
+	}
+	return hist, nil
+}
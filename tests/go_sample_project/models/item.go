@@ -1,14 +1,53 @@
 // tests/sample_project2/models/item.go
 package models
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
 
 // Item represents a single data item to be processed.
+// Its encodings are defined elsewhere, so the struct carries no tags: JSON by
+// itemJSON in json.go, XML by the datahandler XML format.
 type Item struct {
-	ItemID    int
-	Name      string
-	Value     float64
-	Processed bool
+	ItemID          int
+	Name            string
+	Value           float64
+	Processed       bool
+	Category        string
+	ProcessedAt     time.Time
+	Tags            []string
+	ProcessedReason string
+	NormalizedValue float64 // Set by Normalize.
+	Delta           float64 // Change from a processing baseline.
+}
+
+var (
+	clockMu sync.RWMutex
+	clock   = time.Now
+)
+
+// SetClock replaces the time source used by MarkAsProcessed, e.g. with a fixed
+// time in tests. Passing nil restores time.Now.
+func SetClock(now func() time.Time) {
+	clockMu.Lock()
+	defer clockMu.Unlock()
+	if now == nil {
+		now = time.Now
+	}
+	clock = now
+}
+
+// currentTime returns the time reported by the configured clock.
+func currentTime() time.Time {
+	clockMu.RLock()
+	defer clockMu.RUnlock()
+	return clock()
 }
 
 // NewItem is a constructor for the Item struct.
@@ -18,21 +57,82 @@ func NewItem(id int, name string, value float64) *Item {
 		Name:      name,
 		Value:     value,
 		Processed: false, // Default value
+		Category:  "",    // Assigned during processing
 	}
 }
 
-// MarkAsProcessed sets the processed flag to true.
+// MarkAsProcessed sets the processed flag to true and records when it happened.
 // It uses a pointer receiver (*Item) to modify the original struct.
+// It is MarkAsProcessedWithReason with an empty reason.
 func (i *Item) MarkAsProcessed() {
-	fmt.Printf("Model Item %d: Marking '%s' as processed.\n", i.ItemID, i.Name)
+	i.MarkAsProcessedWithReason("")
+}
+
+// MarkAsProcessedWithReason is like MarkAsProcessed but also records why the
+// item was processed in ProcessedReason. An empty reason clears it.
+func (i *Item) MarkAsProcessedWithReason(reason string) {
+	if logger := pkgLogger(); logger.Enabled(context.Background(), slog.LevelDebug) {
+		logger.Debug("Model Item: marking as processed", "item_id", i.ItemID, "name", i.Name, "reason", reason)
+	}
 	i.Processed = true
+	i.ProcessedAt = currentTime()
+	i.ProcessedReason = reason
+}
+
+// Reset returns the item to its unprocessed state, clearing Processed,
+// ProcessedAt, ProcessedReason, Category, Delta and the tags an ItemProcessor
+// adds so it can be run through the pipeline again. Other tags are kept.
+func (i *Item) Reset() {
+	i.Processed = false
+	i.ProcessedAt = time.Time{}
+	i.ProcessedReason = ""
+	i.Category = ""
+	i.Delta = 0
+	i.RemoveTag(TagProcessed)
+	i.RemoveTag(TagChanged)
+	i.RemoveTag(TagNew)
+}
+
+// ResetAll calls Reset on every item in the slice, in place.
+func ResetAll(items []Item) {
+	for idx := range items {
+		items[idx].Reset()
+	}
 }
 
 // String provides a user-friendly string representation, satisfying the fmt.Stringer interface.
+// Value is shown with two decimal places; Category, ProcessedAt, ProcessedReason
+// and Tags are included only when set.
 func (i *Item) String() string {
+	return i.StringWithPrecision(2)
+}
+
+// StringWithPrecision is like String but formats Value with the given number
+// of decimal places. A negative precision uses the fewest digits that
+// represent Value exactly, e.g. 150.75 or 1e-07.
+func (i *Item) StringWithPrecision(places int) string {
 	status := "Pending"
 	if i.Processed {
 		status = "Processed"
 	}
-	return fmt.Sprintf("Item(ID=%d, Name='%s', Value=%.2f, Status=%s)", i.ItemID, i.Name, i.Value, status)
-}
\ No newline at end of file
+	var b strings.Builder
+	value := strconv.FormatFloat(i.Value, 'f', places, 64)
+	if places < 0 {
+		value = strconv.FormatFloat(i.Value, 'g', -1, 64)
+	}
+	fmt.Fprintf(&b, "Item(ID=%d, Name='%s', Value=%s, Status=%s", i.ItemID, i.Name, value, status)
+	if i.Category != "" {
+		fmt.Fprintf(&b, ", Category=%s", i.Category)
+	}
+	if !i.ProcessedAt.IsZero() {
+		fmt.Fprintf(&b, ", ProcessedAt=%s", i.ProcessedAt.Format(time.RFC3339))
+	}
+	if i.ProcessedReason != "" {
+		fmt.Fprintf(&b, ", Reason='%s'", i.ProcessedReason)
+	}
+	if len(i.Tags) > 0 {
+		fmt.Fprintf(&b, ", Tags=%v", i.Tags)
+	}
+	b.WriteString(")")
+	return b.String()
+}
@@ -0,0 +1,67 @@
+// tests/sample_project2/models/json.go
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// itemJSON is the wire form of Item. Processed, ProcessedAt, Tags,
+// ProcessedReason, NormalizedValue and Delta are omitted when unset; ItemID, Name and Value are always written.
+type itemJSON struct {
+	ItemID      int        `json:"ItemID"`
+	Name        string     `json:"Name"`
+	Value       float64    `json:"Value"`
+	Processed   bool       `json:"Processed,omitempty"`
+	Category    string     `json:"Category"`
+	ProcessedAt *time.Time `json:"ProcessedAt,omitempty"`
+	Tags        []string   `json:"Tags,omitempty"`
+	Reason      string     `json:"ProcessedReason,omitempty"`
+	Normalized  float64    `json:"NormalizedValue,omitempty"`
+	Delta       float64    `json:"Delta,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler, leaving out Processed when false,
+// ProcessedAt when zero, Tags and ProcessedReason when empty and
+// NormalizedValue and Delta when 0.
+func (i Item) MarshalJSON() ([]byte, error) {
+	out := itemJSON{
+		ItemID:     i.ItemID,
+		Name:       i.Name,
+		Value:      i.Value,
+		Processed:  i.Processed,
+		Category:   i.Category,
+		Tags:       i.Tags,
+		Reason:     i.ProcessedReason,
+		Normalized: i.NormalizedValue,
+		Delta:      i.Delta,
+	}
+	if !i.ProcessedAt.IsZero() {
+		out.ProcessedAt = &i.ProcessedAt
+	}
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Absent fields take their zero
+// value, so a missing Processed means false.
+func (i *Item) UnmarshalJSON(data []byte) error {
+	var in itemJSON
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+	*i = Item{
+		ItemID:          in.ItemID,
+		Name:            in.Name,
+		Value:           in.Value,
+		Processed:       in.Processed,
+		Category:        in.Category,
+		Tags:            in.Tags,
+		ProcessedReason: in.Reason,
+		NormalizedValue: in.Normalized,
+		Delta:           in.Delta,
+	}
+	if in.ProcessedAt != nil {
+		i.ProcessedAt = *in.ProcessedAt
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+// tests/sample_project2/models/logging.go
+package models
+
+import (
+	"log/slog"
+	"sync"
+)
+
+var (
+	loggerMu sync.RWMutex
+	logger   *slog.Logger
+)
+
+// SetLogger sets the logger used by MarkAsProcessed and the other model helpers,
+// e.g. one writing to a buffer in tests. Passing nil restores slog.Default().
+func SetLogger(l *slog.Logger) {
+	loggerMu.Lock()
+	defer loggerMu.Unlock()
+	logger = l
+}
+
+// pkgLogger returns the logger set with SetLogger, or slog.Default() when none is.
+func pkgLogger() *slog.Logger {
+	loggerMu.RLock()
+	defer loggerMu.RUnlock()
+	if logger == nil {
+		return slog.Default()
+	}
+	return logger
+}
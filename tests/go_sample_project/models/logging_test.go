@@ -0,0 +1,33 @@
+// tests/sample_project2/models/logging_test.go
+package models
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestSetLoggerCapturesOutput(t *testing.T) {
+	var buf bytes.Buffer
+	SetLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	t.Cleanup(func() { SetLogger(nil) })
+
+	item := Item{ItemID: 7, Name: "Gadget Alpha"}
+	item.MarkAsProcessedWithReason("manual")
+
+	out := buf.String()
+	for _, want := range []string{`msg="Model Item: marking as processed"`, "item_id=7", `name="Gadget Alpha"`, "reason=manual"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("log output %q does not contain %q", out, want)
+		}
+	}
+}
+
+func TestSetLoggerNilRestoresDefault(t *testing.T) {
+	SetLogger(slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil)))
+	SetLogger(nil)
+	if got := pkgLogger(); got != slog.Default() {
+		t.Errorf("pkgLogger after SetLogger(nil) = %v, want slog.Default()", got)
+	}
+}
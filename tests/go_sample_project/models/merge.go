@@ -0,0 +1,58 @@
+// tests/sample_project2/models/merge.go
+package models
+
+import "math"
+
+// MergePolicy decides which item Merge keeps when both sets share an ItemID.
+type MergePolicy int
+
+const (
+	// MergeKeepFirst keeps the item that appeared first, i.e. the one from a.
+	MergeKeepFirst MergePolicy = iota
+	// MergeKeepHigherValue keeps the item with the larger Value. NaN loses to
+	// any number; equal values keep the first item.
+	MergeKeepHigherValue
+	// MergeKeepNewer keeps the item with the later ProcessedAt, so an
+	// unprocessed item loses to a processed one. Equal times keep the first item.
+	MergeKeepNewer
+)
+
+// Merge combines a and b by ItemID. Items whose ItemID appears only once pass
+// through unchanged; for a shared ItemID policy picks the winner, which takes
+// the position where that ItemID first appeared. The result lists a's items
+// in order followed by the items only in b, and shares no Tags with either
+// input. An ItemID repeated within one set is merged the same way.
+func Merge(a, b []Item, policy MergePolicy) []Item {
+	firstIndex := make(map[int]int, len(a)+len(b))
+	result := make([]Item, 0, len(a)+len(b))
+	for _, items := range [][]Item{a, b} {
+		for i := range items {
+			item := &items[i]
+			pos, seen := firstIndex[item.ItemID]
+			if !seen {
+				firstIndex[item.ItemID] = len(result)
+				result = append(result, item.Clone())
+				continue
+			}
+			if policy.prefers(*item, result[pos]) {
+				result[pos] = item.Clone()
+			}
+		}
+	}
+	return result
+}
+
+// prefers reports whether candidate should replace current under the policy.
+func (policy MergePolicy) prefers(candidate, current Item) bool {
+	switch policy {
+	case MergeKeepHigherValue:
+		if math.IsNaN(candidate.Value) {
+			return false
+		}
+		return math.IsNaN(current.Value) || candidate.Value > current.Value
+	case MergeKeepNewer:
+		return candidate.ProcessedAt.After(current.ProcessedAt)
+	default:
+		return false
+	}
+}
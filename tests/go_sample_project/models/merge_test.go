@@ -0,0 +1,60 @@
+// tests/sample_project2/models/merge_test.go
+package models
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestMerge(t *testing.T) {
+	earlier := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	later := earlier.Add(time.Hour)
+	a := []Item{
+		{ItemID: 1, Name: "a1", Value: 10},
+		{ItemID: 2, Name: "a2", Value: 20, ProcessedAt: later},
+		{ItemID: 3, Name: "a3", Value: 30},
+	}
+	b := []Item{
+		{ItemID: 3, Name: "b3", Value: 35, ProcessedAt: earlier},
+		{ItemID: 4, Name: "b4", Value: 40},
+		{ItemID: 2, Name: "b2", Value: 15, ProcessedAt: earlier},
+	}
+
+	tests := []struct {
+		policy MergePolicy
+		want   []string // Names in result order
+	}{
+		{MergeKeepFirst, []string{"a1", "a2", "a3", "b4"}},
+		{MergeKeepHigherValue, []string{"a1", "a2", "b3", "b4"}},
+		{MergeKeepNewer, []string{"a1", "a2", "b3", "b4"}},
+	}
+	for _, tt := range tests {
+		got := Merge(a, b, tt.policy)
+		var names []string
+		for _, item := range got {
+			names = append(names, item.Name)
+		}
+		if !reflect.DeepEqual(names, tt.want) {
+			t.Errorf("Merge(policy %d) = %v, want %v", tt.policy, names, tt.want)
+		}
+	}
+}
+
+func TestMergeKeepHigherValueNaN(t *testing.T) {
+	a := []Item{{ItemID: 1, Name: "nan", Value: math.NaN()}}
+	b := []Item{{ItemID: 1, Name: "number", Value: -5}}
+	if got := Merge(a, b, MergeKeepHigherValue); len(got) != 1 || got[0].Name != "number" {
+		t.Errorf("Merge = %v, want the numeric item to beat NaN", got)
+	}
+}
+
+func TestMergeDoesNotShareTags(t *testing.T) {
+	a := []Item{{ItemID: 1, Tags: []string{"red"}}}
+	got := Merge(a, nil, MergeKeepFirst)
+	got[0].Tags[0] = "blue"
+	if a[0].Tags[0] != "red" {
+		t.Errorf("mutating the merged item changed the input tags to %v", a[0].Tags)
+	}
+}
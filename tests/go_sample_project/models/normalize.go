@@ -0,0 +1,26 @@
+// tests/sample_project2/models/normalize.go
+package models
+
+// Normalize rescales every item's Value to [0, 1] using the smallest and
+// largest finite values in the slice, storing the result in NormalizedValue;
+// Value itself is unchanged. When all finite values are equal there is no
+// range to scale by and every item gets 0. Items whose Value is NaN or ±Inf
+// also get 0. An empty slice is a no-op; it returns an error, modifying
+// nothing, if the slice has items but none with a finite value.
+func Normalize(items []Item) error {
+	if len(items) == 0 {
+		return nil
+	}
+	minValue, maxValue, err := ValueRange(items)
+	if err != nil {
+		return err
+	}
+	span := maxValue - minValue
+	for i := range items {
+		items[i].NormalizedValue = 0
+		if isFinite(items[i].Value) && span > 0 {
+			items[i].NormalizedValue = (items[i].Value - minValue) / span
+		}
+	}
+	return nil
+}
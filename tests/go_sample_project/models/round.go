@@ -0,0 +1,76 @@
+// tests/sample_project2/models/round.go
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// RoundValues rounds the Value of every item in place to the given number of
+// decimal places, using round-half-to-even (banker's rounding).
+// Negative places return an error and leave the items unmodified.
+func RoundValues(items []Item, places int) error {
+	if places < 0 {
+		return fmt.Errorf("invalid rounding precision %d: must not be negative", places)
+	}
+	for i := range items {
+		items[i].Value, _ = Round(items[i].Value, places)
+	}
+	return nil
+}
+
+// Round rounds value to the given number of decimal places using
+// round-half-to-even. It works on the shortest decimal representation of value,
+// so 2.675 is treated as exactly 2.675 (a tie, giving 2.68) rather than as the
+// nearest binary float 2.67499999.... NaN and infinities are returned unchanged.
+func Round(value float64, places int) (float64, error) {
+	if places < 0 {
+		return value, fmt.Errorf("invalid rounding precision %d: must not be negative", places)
+	}
+	if !isFinite(value) {
+		return value, nil
+	}
+
+	s := strconv.FormatFloat(value, 'f', -1, 64)
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+	intPart, frac, _ := strings.Cut(s, ".")
+	if len(frac) <= places {
+		return value, nil
+	}
+
+	digits := []byte(intPart + frac[:places])
+	next, rest := frac[places], frac[places+1:]
+	roundUp := next > '5' ||
+		(next == '5' && strings.TrimRight(rest, "0") != "") ||
+		(next == '5' && (digits[len(digits)-1]-'0')%2 == 1)
+	if roundUp {
+		digits = incrementDecimal(digits)
+	}
+
+	point := len(digits) - places
+	rounded := string(digits[:point])
+	if places > 0 {
+		rounded += "." + string(digits[point:])
+	}
+	result, err := strconv.ParseFloat(sign+rounded, 64)
+	if result == 0 {
+		result = 0 // avoid -0 when a small negative value rounds away
+	}
+	return result, err
+}
+
+// incrementDecimal adds one to the decimal number held in digits, carrying as needed.
+func incrementDecimal(digits []byte) []byte {
+	for i := len(digits) - 1; i >= 0; i-- {
+		if digits[i] < '9' {
+			digits[i]++
+			return digits
+		}
+		digits[i] = '0'
+	}
+	return append([]byte{'1'}, digits...)
+}
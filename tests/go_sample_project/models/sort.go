@@ -0,0 +1,53 @@
+// tests/sample_project2/models/sort.go
+package models
+
+import (
+	"math"
+	"sort"
+)
+
+// SortByValue sorts items in place by Value, ascending unless descending is true.
+// Items with equal values are ordered by ItemID ascending so the result is deterministic.
+func SortByValue(items []Item, descending bool) {
+	sort.Slice(items, func(a, b int) bool {
+		if items[a].Value != items[b].Value {
+			if descending {
+				return items[a].Value > items[b].Value
+			}
+			return items[a].Value < items[b].Value
+		}
+		return items[a].ItemID < items[b].ItemID
+	})
+}
+
+// SortByName sorts items in place by Name, ascending.
+// Items with equal names are ordered by ItemID ascending.
+func SortByName(items []Item) {
+	sort.Slice(items, func(a, b int) bool {
+		if items[a].Name != items[b].Name {
+			return items[a].Name < items[b].Name
+		}
+		return items[a].ItemID < items[b].ItemID
+	})
+}
+
+// HigherPriority reports whether a should be processed before b: higher Value
+// first, then lower ItemID. NaN values sort after every other value.
+func HigherPriority(a, b Item) bool {
+	aNaN, bNaN := math.IsNaN(a.Value), math.IsNaN(b.Value)
+	if aNaN != bNaN {
+		return bNaN
+	}
+	if !aNaN && a.Value != b.Value {
+		return a.Value > b.Value
+	}
+	return a.ItemID < b.ItemID
+}
+
+// SortByPriority sorts items in place so the most valuable come first,
+// using HigherPriority.
+func SortByPriority(items []Item) {
+	sort.Slice(items, func(a, b int) bool {
+		return HigherPriority(items[a], items[b])
+	})
+}
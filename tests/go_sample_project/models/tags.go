@@ -0,0 +1,56 @@
+// tests/sample_project2/models/tags.go
+package models
+
+// Tags added by an ItemProcessor: TagProcessed to every item it processes, and
+// TagChanged or TagNew when a baseline comparison flags the item.
+const (
+	TagProcessed = "processed"
+	TagChanged   = "changed"
+	TagNew       = "new"
+)
+
+// HasTag reports whether the item carries tag. Matching is exact and case-sensitive.
+func (i *Item) HasTag(tag string) bool {
+	for _, t := range i.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// AddTag appends tag unless the item already has it; empty tags are ignored.
+// It works on a nil Tags slice and never writes into a backing array shared
+// with a copy of the item.
+func (i *Item) AddTag(tag string) {
+	if tag == "" || i.HasTag(tag) {
+		return
+	}
+	i.Tags = append(i.Tags[:len(i.Tags):len(i.Tags)], tag)
+}
+
+// RemoveTag removes tag if present, leaving Tags nil when it was the last one.
+func (i *Item) RemoveTag(tag string) {
+	if !i.HasTag(tag) {
+		return
+	}
+	var kept []string
+	for _, t := range i.Tags {
+		if t != tag {
+			kept = append(kept, t)
+		}
+	}
+	i.Tags = kept
+}
+
+// FilterByTag returns the items that carry tag. The input slice is not modified
+// and the result is never nil.
+func FilterByTag(items []Item, tag string) []Item {
+	tagged := make([]Item, 0, len(items))
+	for _, item := range items {
+		if item.HasTag(tag) {
+			tagged = append(tagged, item)
+		}
+	}
+	return tagged
+}
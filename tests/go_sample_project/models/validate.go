@@ -0,0 +1,24 @@
+// tests/sample_project2/models/validate.go
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Validate checks that item has a positive ItemID, a non-empty Name and a
+// non-negative Value. Every violation is reported in the returned error.
+func Validate(item Item) error {
+	var errs []error
+	if item.ItemID <= 0 {
+		errs = append(errs, fmt.Errorf("ItemID must be positive, got %d", item.ItemID))
+	}
+	if strings.TrimSpace(item.Name) == "" {
+		errs = append(errs, errors.New("Name must not be empty"))
+	}
+	if item.Value < 0 {
+		errs = append(errs, fmt.Errorf("Value must not be negative, got %.2f", item.Value))
+	}
+	return errors.Join(errs...)
+}
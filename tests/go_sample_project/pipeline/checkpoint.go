@@ -0,0 +1,96 @@
+// tests/sample_project2/pipeline/checkpoint.go
+package pipeline
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sourcelens/sampleproject2/models"
+)
+
+// checkpoint is the content of the sidecar file written by WithCheckpoint:
+// the items processed so far, in processing order, with their results.
+type checkpoint struct {
+	LastItemID int           `json:"last_item_id"`
+	Items      []models.Item `json:"items"`
+}
+
+// newCheckpoint records the items at the done indexes.
+func newCheckpoint(items []models.Item, done []int) checkpoint {
+	cp := checkpoint{Items: make([]models.Item, len(done))}
+	for i, idx := range done {
+		cp.Items[i] = items[idx]
+	}
+	if len(done) > 0 {
+		cp.LastItemID = items[done[len(done)-1]].ItemID
+	}
+	return cp
+}
+
+// readCheckpoint loads the checkpoint at path. A missing file is not an error
+// and reports ok == false.
+func readCheckpoint(path string) (cp checkpoint, ok bool, err error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return cp, false, nil
+	}
+	if err != nil {
+		return cp, false, fmt.Errorf("failed to read checkpoint: %w", err)
+	}
+	if err := json.Unmarshal(data, &cp); err != nil {
+		return cp, false, fmt.Errorf("failed to parse checkpoint %s: %w", path, err)
+	}
+	return cp, true, nil
+}
+
+// writeCheckpoint atomically replaces the checkpoint at path, so a crash
+// mid-write leaves the previous checkpoint intact.
+func writeCheckpoint(path string, cp checkpoint) error {
+	data, err := json.Marshal(cp)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	defer os.Remove(tmp.Name()) // no-op once renamed
+
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	return nil
+}
+
+// resumeFrom replaces each item in order that cp recorded, matched by
+// ItemID, with its checkpointed version. It returns the entries of order
+// still to be processed and those restored. The decision rests on the
+// checkpoint alone, so it holds whatever the store reads back, in any
+// processing order, and items that failed before the crash are retried.
+func resumeFrom(items []models.Item, order []int, cp checkpoint) (remaining, restored []int) {
+	byID := make(map[int]models.Item, len(cp.Items))
+	for _, item := range cp.Items {
+		byID[item.ItemID] = item
+	}
+	remaining = make([]int, 0, len(order))
+	for _, idx := range order {
+		item, ok := byID[items[idx].ItemID]
+		if !ok {
+			remaining = append(remaining, idx)
+			continue
+		}
+		items[idx] = item
+		restored = append(restored, idx)
+	}
+	return remaining, restored
+}
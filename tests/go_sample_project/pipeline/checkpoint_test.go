@@ -0,0 +1,247 @@
+// tests/sample_project2/pipeline/checkpoint_test.go
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sourcelens/sampleproject2/datahandler"
+	"sourcelens/sampleproject2/itemprocessor"
+	"sourcelens/sampleproject2/models"
+	"testing"
+)
+
+var discardLogger = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+// sampleItems mirrors data/items.json.
+func sampleItems() []models.Item {
+	return []models.Item{
+		{ItemID: 1, Name: "Gadget Alpha", Value: 150.75},
+		{ItemID: 2, Name: "Widget Beta", Value: 85.0},
+		{ItemID: 3, Name: "Thingamajig Gamma", Value: 210.5},
+		{ItemID: 4, Name: "Doohickey Delta", Value: 55.2},
+	}
+}
+
+// checkpointFixture writes the sample items to a data file in a temp dir and
+// returns a store for it and the checkpoint path next to it.
+func checkpointFixture(t *testing.T) (*datahandler.DataHandler, string) {
+	t.Helper()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "items.json")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := datahandler.SaveItemsTo(f, sampleItems()); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return datahandler.NewDataHandler(path, datahandler.WithLogger(discardLogger)), filepath.Join(dir, "items.checkpoint")
+}
+
+// recordingProcessor returns a processor whose rule records each ItemID it
+// sees and returns fail(id) as the rule's error.
+func recordingProcessor(seen *[]int, fail func(id int) error) *itemprocessor.ItemProcessor {
+	return itemprocessor.NewItemProcessor(100, itemprocessor.WithSilent(), itemprocessor.WithRules(itemprocessor.Rule{
+		Name: "record",
+		Func: func(item *models.Item) error {
+			*seen = append(*seen, item.ItemID)
+			return fail(item.ItemID)
+		},
+	}))
+}
+
+func assertAllProcessed(t *testing.T, store *datahandler.DataHandler, checkpointPath string) {
+	t.Helper()
+	items, err := store.LoadItems()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, item := range items {
+		if !item.Processed {
+			t.Errorf("item %d not processed after resumed run", item.ItemID)
+		}
+	}
+	if _, err := os.Stat(checkpointPath); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("checkpoint still present after completed run: %v", err)
+	}
+}
+
+func checkpointIDs(cp checkpoint) []int {
+	var ids []int
+	for _, item := range cp.Items {
+		ids = append(ids, item.ItemID)
+	}
+	return ids
+}
+
+func TestCheckpointResumesAfterCrash(t *testing.T) {
+	store, cpPath := checkpointFixture(t)
+	crash := errors.New("crash")
+
+	var first []int
+	proc := recordingProcessor(&first, func(id int) error {
+		if id == 3 {
+			return crash
+		}
+		return nil
+	})
+	_, err := New(store, proc).WithLogger(discardLogger).WithCheckpoint(cpPath).Run(context.Background())
+	if !errors.Is(err, crash) {
+		t.Fatalf("first run error = %v, want %v", err, crash)
+	}
+
+	// Work done before the crash must be in the checkpoint.
+	cp, ok, err := readCheckpoint(cpPath)
+	if err != nil || !ok {
+		t.Fatalf("readCheckpoint = %v, %v", ok, err)
+	}
+	if got := checkpointIDs(cp); !reflect.DeepEqual(got, []int{1, 2}) || cp.LastItemID != 2 {
+		t.Errorf("checkpoint holds %v (last %d), want [1 2] (last 2)", got, cp.LastItemID)
+	}
+
+	var second []int
+	proc = recordingProcessor(&second, func(int) error { return nil })
+	if _, err := New(store, proc).WithLogger(discardLogger).WithCheckpoint(cpPath).Run(context.Background()); err != nil {
+		t.Fatalf("resumed run: %v", err)
+	}
+	if want := []int{3, 4}; !reflect.DeepEqual(second, want) {
+		t.Errorf("resumed run processed %v, want %v", second, want)
+	}
+	assertAllProcessed(t, store, cpPath)
+}
+
+func TestCheckpointRetriesFailedItems(t *testing.T) {
+	store, cpPath := checkpointFixture(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var first []int
+	proc := recordingProcessor(&first, func(id int) error {
+		switch id {
+		case 2:
+			return errors.New("bad item")
+		case 3:
+			cancel() // interrupt the run once item 3 completes
+		}
+		return nil
+	})
+	_, err := New(store, proc).WithLogger(discardLogger).WithContinueOnError(true).WithCheckpoint(cpPath).Run(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("first run error = %v, want context.Canceled", err)
+	}
+
+	// The checkpoint now names item 3, past the failed item 2, which must
+	// still be retried.
+	var second []int
+	proc = recordingProcessor(&second, func(int) error { return nil })
+	if _, err := New(store, proc).WithLogger(discardLogger).WithCheckpoint(cpPath).Run(context.Background()); err != nil {
+		t.Fatalf("resumed run: %v", err)
+	}
+	if want := []int{2, 4}; !reflect.DeepEqual(second, want) {
+		t.Errorf("resumed run processed %v, want %v", second, want)
+	}
+	assertAllProcessed(t, store, cpPath)
+}
+
+func TestCheckpointResumesPrioritizedOrder(t *testing.T) {
+	store, cpPath := checkpointFixture(t)
+	crash := errors.New("crash")
+
+	var first []int
+	failAfter := 2
+	proc := recordingProcessor(&first, func(int) error {
+		if len(first) > failAfter {
+			return crash
+		}
+		return nil
+	})
+	_, err := New(store, proc).WithLogger(discardLogger).WithPrioritize(true).WithCheckpoint(cpPath).Run(context.Background())
+	if !errors.Is(err, crash) {
+		t.Fatalf("first run error = %v, want %v", err, crash)
+	}
+
+	var second []int
+	proc = recordingProcessor(&second, func(int) error { return nil })
+	if _, err := New(store, proc).WithLogger(discardLogger).WithPrioritize(true).WithCheckpoint(cpPath).Run(context.Background()); err != nil {
+		t.Fatalf("resumed run: %v", err)
+	}
+	// Each item succeeds exactly once across both runs; the one that crashed
+	// is retried.
+	done := map[int]int{}
+	for _, id := range first[:failAfter] {
+		done[id]++
+	}
+	for _, id := range second {
+		done[id]++
+	}
+	for _, item := range sampleItems() {
+		if done[item.ItemID] != 1 {
+			t.Errorf("item %d succeeded %d times (first run %v, resumed run %v), want 1", item.ItemID, done[item.ItemID], first, second)
+		}
+	}
+	assertAllProcessed(t, store, cpPath)
+}
+
+func TestCheckpointResumesWhenOutputIsNotReadBack(t *testing.T) {
+	// A MemoryStore always loads its seed, like a source with WithOutputPath.
+	store := datahandler.NewMemoryStore(sampleItems())
+	cpPath := filepath.Join(t.TempDir(), "items.checkpoint")
+	crash := errors.New("crash")
+
+	var first []int
+	proc := recordingProcessor(&first, func(id int) error {
+		if id == 3 {
+			return crash
+		}
+		return nil
+	})
+	if _, err := New(store, proc).WithLogger(discardLogger).WithCheckpoint(cpPath).Run(context.Background()); !errors.Is(err, crash) {
+		t.Fatalf("first run error = %v, want %v", err, crash)
+	}
+
+	var second []int
+	proc = recordingProcessor(&second, func(int) error { return nil })
+	if _, err := New(store, proc).WithLogger(discardLogger).WithCheckpoint(cpPath).Run(context.Background()); err != nil {
+		t.Fatalf("resumed run: %v", err)
+	}
+	if want := []int{3, 4}; !reflect.DeepEqual(second, want) {
+		t.Errorf("resumed run processed %v, want %v", second, want)
+	}
+	// The restored items keep their results from the first run.
+	for _, item := range store.Saved() {
+		if !item.Processed {
+			t.Errorf("saved item %d not processed", item.ItemID)
+		}
+	}
+}
+
+func TestCheckpointEvery(t *testing.T) {
+	store := datahandler.NewMemoryStore(sampleItems())
+	cpPath := filepath.Join(t.TempDir(), "items.checkpoint")
+
+	// Each rule call records how many items the checkpoint held at that point.
+	held := map[int][]int{}
+	proc := itemprocessor.NewItemProcessor(100, itemprocessor.WithSilent(), itemprocessor.WithRules(itemprocessor.Rule{
+		Name: "inspect",
+		Func: func(item *models.Item) error {
+			cp, _, err := readCheckpoint(cpPath)
+			held[item.ItemID] = checkpointIDs(cp)
+			return err
+		},
+	}))
+	if _, err := New(store, proc).WithLogger(discardLogger).WithCheckpoint(cpPath).WithCheckpointEvery(2).Run(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	want := map[int][]int{1: nil, 2: nil, 3: {1, 2}, 4: {1, 2}}
+	if !reflect.DeepEqual(held, want) {
+		t.Errorf("checkpoint contents seen by each item = %v, want %v", held, want)
+	}
+}
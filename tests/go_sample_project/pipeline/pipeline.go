@@ -0,0 +1,432 @@
+// tests/sample_project2/pipeline/pipeline.go
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"slices"
+	"sort"
+	"sourcelens/sampleproject2/datahandler"
+	"sourcelens/sampleproject2/itemprocessor"
+	"sourcelens/sampleproject2/models"
+)
+
+// Pipeline composes a DataStore and a Processor into a single
+// load → process → save run.
+type Pipeline struct {
+	store           datahandler.DataStore
+	proc            itemprocessor.Processor
+	logger          *slog.Logger
+	dryRun          bool
+	continueOnError bool
+	prioritize      bool
+	checkpointPath  string
+	checkpointEvery int
+	filter          func(models.Item) bool
+	ids             []int
+	minItems        int
+	progress        func(done, total int)
+	progressEvery   int
+
+	// stats is tallied from ProcessResults when proc does not implement
+	// itemprocessor.StatsReporter.
+	stats itemprocessor.StatsCollector
+}
+
+// ErrTooFewItems is returned by Run when fewer items load than WithRequireItems demands.
+var ErrTooFewItems = errors.New("too few items loaded")
+
+// SaveError is returned by Run when persisting the items fails. After a
+// cancellation it is joined with ctx.Err(), so callers can tell an interrupted
+// run whose partial results were saved from one that also lost them.
+type SaveError struct {
+	Err error
+}
+
+// Error implements the error interface.
+func (e *SaveError) Error() string {
+	return fmt.Sprintf("failed to save items: %v", e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *SaveError) Unwrap() error {
+	return e.Err
+}
+
+// New is a constructor for the Pipeline. By default it stops at the first
+// item failure, saves its results and logs to slog.Default().
+// proc is usually an *itemprocessor.ItemProcessor but may be any Processor.
+func New(store datahandler.DataStore, proc itemprocessor.Processor) *Pipeline {
+	return &Pipeline{store: store, proc: proc, logger: slog.Default()}
+}
+
+// WithLogger sets the logger used by the pipeline. A nil logger means slog.Default().
+func (p *Pipeline) WithLogger(logger *slog.Logger) *Pipeline {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	p.logger = logger
+	return p
+}
+
+// WithDryRun makes Run load and process items normally but log the items
+// instead of saving them. Stats still report how many would have been saved.
+func (p *Pipeline) WithDryRun(dryRun bool) *Pipeline {
+	p.dryRun = dryRun
+	return p
+}
+
+// WithContinueOnError makes Run keep going after an item fails, reporting the
+// failures in Stats.Errors. Otherwise the first failure aborts the run before saving.
+func (p *Pipeline) WithContinueOnError(continueOnError bool) *Pipeline {
+	p.continueOnError = continueOnError
+	return p
+}
+
+// WithPrioritize makes Run process items in descending Value order (see
+// models.HigherPriority), so that under a deadline the most valuable items
+// complete first. Items are still saved in the order they were loaded.
+func (p *Pipeline) WithPrioritize(prioritize bool) *Pipeline {
+	p.prioritize = prioritize
+	return p
+}
+
+// WithIDFilter restricts processing to items whose ItemID is one of ids, e.g.
+// to reprocess specific records. Other items are loaded and saved unchanged
+// and counted in Stats.SkippedCount, as with WithFilter; when both are set an
+// item must pass both. Requested IDs that are not in the data are logged and
+// reported in Stats.MissingIDs. No ids processes every item.
+func (p *Pipeline) WithIDFilter(ids ...int) *Pipeline {
+	p.ids = nil
+	if len(ids) > 0 {
+		p.ids = slices.Clone(ids)
+		slices.Sort(p.ids)
+		p.ids = slices.Compact(p.ids)
+	}
+	return p
+}
+
+// WithRequireItems makes Run fail with ErrTooFewItems, before processing or
+// saving anything, when fewer than min items are loaded. The default of 0
+// accepts an empty data source.
+func (p *Pipeline) WithRequireItems(min int) *Pipeline {
+	p.minItems = min
+	return p
+}
+
+// WithFilter restricts processing to items for which keep returns true.
+// Other items are loaded and saved unchanged, and counted in Stats.SkippedCount.
+// A nil predicate processes every item.
+func (p *Pipeline) WithFilter(keep func(models.Item) bool) *Pipeline {
+	p.filter = keep
+	return p
+}
+
+// WithProgress makes Run call fn with the number of items handled so far and
+// the number it will handle in total: once with done == 0 before the first
+// item, again after every item, whether it was processed, filtered out or
+// failed, and once with done == total at the end. WithProgressEvery reports
+// less often. Items skipped by a checkpoint are not part of total.
+// fn runs synchronously on the processing goroutine, so it must return
+// quickly. A nil fn disables progress reports.
+func (p *Pipeline) WithProgress(fn func(done, total int)) *Pipeline {
+	p.progress = fn
+	return p
+}
+
+// WithProgressEvery throttles the WithProgress callback to every n items:
+// it still runs with done == 0 and done == total, and in between only when
+// done is a multiple of n. n <= 1 reports after every item.
+func (p *Pipeline) WithProgressEvery(n int) *Pipeline {
+	p.progressEvery = n
+	return p
+}
+
+// WithCheckpoint makes Run keep a sidecar file at path holding the items it
+// has processed successfully, rewritten every WithCheckpointEvery items and
+// whenever the run stops early. When Run starts and the file exists, those
+// items replace their loaded counterparts, by ItemID, and are not processed
+// again, so a crashed run resumes where it left off and the final save keeps
+// their results whatever the store reads back. Failed items are not recorded
+// and are retried. Without the file everything is processed. The file is
+// removed once a run completes and saves its results. In dry-run mode an
+// existing checkpoint is honored but never written or removed.
+// An empty path disables checkpointing.
+func (p *Pipeline) WithCheckpoint(path string) *Pipeline {
+	p.checkpointPath = path
+	return p
+}
+
+// DefaultCheckpointEvery is how many processed items WithCheckpoint batches
+// into each checkpoint write unless WithCheckpointEvery says otherwise.
+const DefaultCheckpointEvery = 100
+
+// WithCheckpointEvery sets how many successfully processed items Run collects
+// before rewriting the WithCheckpoint file. A crash loses at most that many
+// items of work, which are processed again on restart; larger values write
+// less often. n <= 0 means DefaultCheckpointEvery.
+func (p *Pipeline) WithCheckpointEvery(n int) *Pipeline {
+	p.checkpointEvery = n
+	return p
+}
+
+// Run loads items from the store, processes each one and saves the results.
+// The data source is checked first when the store implements datahandler.Pinger,
+// and the load itself honors ctx when the store implements datahandler.ContextLoader.
+// Canceling ctx stops processing before the next item; the items are then
+// still saved, so work already done is not lost, and ctx.Err() is returned,
+// joined with a *SaveError if that save fails.
+// Every loaded item is written back, processed or not, so a data file that is
+// both source and destination keeps its unprocessed items.
+// The returned Stats describe the items processed before the run stopped.
+func (p *Pipeline) Run(ctx context.Context) (itemprocessor.Stats, error) {
+	// 1. Load data, checking first that the source is reachable when the store supports it
+	if pinger, ok := p.store.(datahandler.Pinger); ok {
+		if err := pinger.Ping(); err != nil {
+			return p.procStats(), fmt.Errorf("data source check failed: %w", err)
+		}
+	}
+	items, err := p.load(ctx)
+	if err != nil {
+		return p.procStats(), fmt.Errorf("failed to load items: %w", err)
+	}
+
+	if len(items) < p.minItems {
+		return p.procStats(), fmt.Errorf("%w: got %d, want at least %d", ErrTooFewItems, len(items), p.minItems)
+	}
+	missing := p.missingIDs(items)
+	if len(missing) > 0 {
+		p.logger.Warn("Requested items not found", "item_ids", missing)
+	}
+	if len(items) == 0 {
+		p.logger.Info("No items loaded. Exiting pipeline.")
+		stats := p.procStats()
+		stats.MissingIDs = missing
+		return stats, nil
+	}
+	p.logger.Info("Successfully loaded items", "count", len(items))
+
+	// 2. Process data items
+	var itemErrs []error
+	skipped := 0
+	snapshot := func() itemprocessor.Stats {
+		stats := p.procStats()
+		stats.Errors = itemErrs
+		stats.SkippedCount = skipped
+		stats.MissingIDs = missing
+		return stats
+	}
+	order, done, err := p.resume(items, p.order(items))
+	if err != nil {
+		return p.procStats(), err
+	}
+	pending := 0 // items processed since the checkpoint was last written
+	flush := func() error {
+		if !p.checkpointing() || pending == 0 {
+			return nil
+		}
+		pending = 0
+		return writeCheckpoint(p.checkpointPath, newCheckpoint(items, done))
+	}
+	canceled := func(completed int) (itemprocessor.Stats, error) {
+		p.logger.Warn("Pipeline canceled, saving partial results", "completed", completed, "total", len(order), "error", ctx.Err())
+		cpErr := flush()
+		stats, saveErr := p.save(items, snapshot())
+		return stats, errors.Join(ctx.Err(), cpErr, saveErr)
+	}
+	for i, idx := range order {
+		p.reportProgress(i, len(order))
+		if ctx.Err() != nil {
+			return canceled(i)
+		}
+		item := &items[idx] // Get a pointer to the item in the slice
+		if !p.selected(*item) {
+			p.logger.Debug("Item excluded by filter", "item_id", item.ItemID)
+			skipped++
+			continue
+		}
+		p.logger.Debug("Passing item to processor", "item", item.String())
+		if err := p.process(ctx, item); err != nil {
+			if ctx.Err() != nil {
+				return canceled(i)
+			}
+			if !p.continueOnError {
+				return snapshot(), errors.Join(fmt.Errorf("failed to process item %d: %w", item.ItemID, err), flush())
+			}
+			p.logger.Error("Failed to process item", "item_id", item.ItemID, "error", err)
+			itemErrs = append(itemErrs, fmt.Errorf("item %d: %w", item.ItemID, err))
+			continue
+		}
+		done = append(done, idx)
+		if pending++; pending >= p.checkpointInterval() {
+			if err := flush(); err != nil {
+				return snapshot(), err
+			}
+		}
+	}
+
+	p.reportProgress(len(order), len(order))
+
+	// 3. Save processed data
+	stats, err := p.save(items, snapshot())
+	if err != nil || !p.checkpointing() {
+		return stats, err
+	}
+	if err := os.Remove(p.checkpointPath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return stats, fmt.Errorf("failed to remove checkpoint: %w", err)
+	}
+	return stats, nil
+}
+
+// procStats returns the processor's statistics when it reports them, or the
+// ones tallied by process otherwise.
+func (p *Pipeline) procStats() itemprocessor.Stats {
+	if reporter, ok := p.proc.(itemprocessor.StatsReporter); ok {
+		return reporter.Stats()
+	}
+	return p.stats.Snapshot()
+}
+
+// process passes item to the processor, tallying the result for procStats
+// when the processor does not keep statistics itself.
+func (p *Pipeline) process(ctx context.Context, item *models.Item) error {
+	result, err := p.proc.ProcessItem(ctx, item)
+	if _, ok := p.proc.(itemprocessor.StatsReporter); ok {
+		return err
+	}
+	switch {
+	case err != nil:
+		p.stats.Add(itemprocessor.Stats{TotalItems: 1})
+	case result.Skipped:
+		p.stats.Add(itemprocessor.Stats{AlreadyProcessedCount: 1})
+	default:
+		delta := itemprocessor.Stats{
+			TotalItems:     1,
+			ProcessedCount: 1,
+			SumValue:       item.Value,
+			MinDuration:    result.Duration,
+			MaxDuration:    result.Duration,
+			TotalDuration:  result.Duration,
+		}
+		if result.ExceededThreshold {
+			delta.ExceededThreshold = 1
+		}
+		p.stats.Add(delta)
+	}
+	return err
+}
+
+// selected reports whether item passes both WithFilter and WithIDFilter.
+func (p *Pipeline) selected(item models.Item) bool {
+	if p.ids != nil {
+		if _, found := slices.BinarySearch(p.ids, item.ItemID); !found {
+			return false
+		}
+	}
+	return p.filter == nil || p.filter(item)
+}
+
+// missingIDs returns the WithIDFilter IDs that no loaded item has, in ascending order.
+func (p *Pipeline) missingIDs(items []models.Item) []int {
+	if p.ids == nil {
+		return nil
+	}
+	present := make(map[int]bool, len(items))
+	for _, item := range items {
+		present[item.ItemID] = true
+	}
+	var missing []int
+	for _, id := range p.ids {
+		if !present[id] {
+			missing = append(missing, id)
+		}
+	}
+	return missing
+}
+
+// reportProgress passes done and total to the WithProgress callback, if any,
+// skipping the reports WithProgressEvery throttles away.
+func (p *Pipeline) reportProgress(done, total int) {
+	if p.progress == nil {
+		return
+	}
+	if done == 0 || done == total || p.progressEvery <= 1 || done%p.progressEvery == 0 {
+		p.progress(done, total)
+	}
+}
+
+// load reads the items, honoring ctx when the store implements datahandler.ContextLoader.
+func (p *Pipeline) load(ctx context.Context) ([]models.Item, error) {
+	if loader, ok := p.store.(datahandler.ContextLoader); ok {
+		return loader.LoadItemsCtx(ctx)
+	}
+	return p.store.LoadItems()
+}
+
+// checkpointing reports whether Run should write and remove the checkpoint file.
+func (p *Pipeline) checkpointing() bool {
+	return p.checkpointPath != "" && !p.dryRun
+}
+
+// checkpointInterval returns the WithCheckpointEvery setting or its default.
+func (p *Pipeline) checkpointInterval() int {
+	if p.checkpointEvery <= 0 {
+		return DefaultCheckpointEvery
+	}
+	return p.checkpointEvery
+}
+
+// resume restores the items recorded in the checkpoint file, if any, into
+// items and drops them from order. It returns the remaining order and the
+// indexes of the restored items.
+func (p *Pipeline) resume(items []models.Item, order []int) (remaining, restored []int, err error) {
+	if p.checkpointPath == "" {
+		return order, nil, nil
+	}
+	cp, ok, err := readCheckpoint(p.checkpointPath)
+	if err != nil || !ok {
+		return order, nil, err
+	}
+	remaining, restored = resumeFrom(items, order, cp)
+	p.logger.Info("Resuming from checkpoint", "checkpoint", p.checkpointPath, "last_item_id", cp.LastItemID, "skipped", len(restored))
+	return remaining, restored, nil
+}
+
+// order returns the indexes of items in the order they should be processed.
+func (p *Pipeline) order(items []models.Item) []int {
+	order := make([]int, len(items))
+	for i := range order {
+		order[i] = i
+	}
+	if p.prioritize {
+		sort.SliceStable(order, func(a, b int) bool {
+			return models.HigherPriority(items[order[a]], items[order[b]])
+		})
+	}
+	return order
+}
+
+// save persists items, or only logs them in dry-run mode, and records the
+// outcome in stats.
+func (p *Pipeline) save(items []models.Item, stats itemprocessor.Stats) (itemprocessor.Stats, error) {
+	if p.dryRun {
+		for i := range items {
+			p.logger.Info("Dry run: would save item", "item", items[i].String())
+		}
+		stats.SavedCount = len(items)
+		stats.DryRun = true
+		p.logger.Info("Dry run: skipped save operation", "count", len(items))
+		return stats, nil
+	}
+	saved, err := p.store.SaveItems(items)
+	if err != nil {
+		return stats, &SaveError{Err: err}
+	}
+	stats.SavedCount = saved
+	p.logger.Info("Processed items saved successfully", "count", saved)
+	return stats, nil
+}
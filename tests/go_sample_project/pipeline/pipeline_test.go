@@ -0,0 +1,165 @@
+// tests/sample_project2/pipeline/pipeline_test.go
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sourcelens/sampleproject2/datahandler"
+	"sourcelens/sampleproject2/itemprocessor"
+	"sourcelens/sampleproject2/models"
+	"testing"
+)
+
+// fakeProcessor is a Processor that marks every item processed except those
+// in fail, recording the ItemIDs it is given. It keeps no statistics, so Run
+// tallies them itself.
+type fakeProcessor struct {
+	fail map[int]error
+	seen []int
+}
+
+var _ itemprocessor.Processor = (*fakeProcessor)(nil)
+
+func (f *fakeProcessor) ProcessItem(ctx context.Context, item *models.Item) (itemprocessor.ProcessResult, error) {
+	f.seen = append(f.seen, item.ItemID)
+	result := itemprocessor.ProcessResult{ItemID: item.ItemID}
+	if err := f.fail[item.ItemID]; err != nil {
+		return result, err
+	}
+	item.MarkAsProcessed()
+	result.ExceededThreshold = item.Value > 100
+	return result, nil
+}
+
+func TestRunWithFakeProcessor(t *testing.T) {
+	errBad := errors.New("bad item")
+	proc := &fakeProcessor{fail: map[int]error{2: errBad}}
+	store := datahandler.NewMemoryStore(sampleItems())
+
+	stats, err := New(store, proc).WithLogger(discardLogger).WithContinueOnError(true).Run(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []int{1, 2, 3, 4}; !reflect.DeepEqual(proc.seen, want) {
+		t.Errorf("processor saw %v, want %v", proc.seen, want)
+	}
+	if stats.TotalItems != 4 || stats.ProcessedCount != 3 || stats.ExceededThreshold != 2 || stats.SavedCount != 4 {
+		t.Errorf("stats = %+v, want 4 total, 3 processed, 2 exceeded, 4 saved", stats)
+	}
+	if len(stats.Errors) != 1 || !errors.Is(stats.Errors[0], errBad) {
+		t.Errorf("stats.Errors = %v, want the one failure for item 2", stats.Errors)
+	}
+	if want := 150.75 + 210.5 + 55.2; stats.SumValue != want {
+		t.Errorf("stats.SumValue = %v, want %v", stats.SumValue, want)
+	}
+}
+
+func TestRunSavesToMemoryStore(t *testing.T) {
+	store := datahandler.NewMemoryStore(sampleItems())
+	if got := store.Saved(); got != nil {
+		t.Fatalf("Saved before Run = %v, want nil", got)
+	}
+
+	proc := itemprocessor.NewItemProcessor(100, itemprocessor.WithSilent())
+	stats, err := New(store, proc).WithLogger(discardLogger).Run(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	saved := store.Saved()
+	if len(saved) != len(sampleItems()) || stats.SavedCount != len(saved) {
+		t.Fatalf("saved %d items (SavedCount %d), want %d", len(saved), stats.SavedCount, len(sampleItems()))
+	}
+	for i, item := range saved {
+		want := sampleItems()[i]
+		if item.ItemID != want.ItemID || item.Value != want.Value {
+			t.Errorf("saved item %d = %+v, want %+v", i, item, want)
+		}
+		if !item.Processed || !item.HasTag(models.TagProcessed) || item.Category == "" {
+			t.Errorf("saved item %d = %+v, want it processed, tagged and categorized", i, item)
+		}
+	}
+
+	// The seeded items are untouched, so a second load starts afresh.
+	loaded, err := store.LoadItems()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(loaded, sampleItems()) {
+		t.Errorf("LoadItems after Run = %+v, want the seeded items", loaded)
+	}
+}
+
+// failingSaveStore is a MemoryStore whose saves always fail.
+type failingSaveStore struct {
+	*datahandler.MemoryStore
+	err error
+}
+
+func (s failingSaveStore) SaveItems([]models.Item) (int, error) {
+	return 0, s.err
+}
+
+func TestRunCanceledReportsSaveError(t *testing.T) {
+	diskFull := errors.New("disk full")
+	store := failingSaveStore{MemoryStore: datahandler.NewMemoryStore(sampleItems()), err: diskFull}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := New(store, itemprocessor.NewPassthrough(itemprocessor.WithSilent())).WithLogger(discardLogger).Run(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Run error = %v, want context.Canceled", err)
+	}
+	var saveErr *SaveError
+	if !errors.As(err, &saveErr) || !errors.Is(saveErr, diskFull) {
+		t.Errorf("Run error = %v, want a *SaveError wrapping %v", err, diskFull)
+	}
+}
+
+func TestRunCanceledSavesPartialResults(t *testing.T) {
+	store := datahandler.NewMemoryStore(sampleItems())
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := New(store, itemprocessor.NewPassthrough(itemprocessor.WithSilent())).WithLogger(discardLogger).Run(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Run error = %v, want context.Canceled", err)
+	}
+	var saveErr *SaveError
+	if errors.As(err, &saveErr) {
+		t.Errorf("Run error = %v, want no *SaveError when the partial save succeeds", err)
+	}
+	if got := len(store.Saved()); got != len(sampleItems()) {
+		t.Errorf("saved %d items, want %d", got, len(sampleItems()))
+	}
+}
+
+func TestWithProgressEvery(t *testing.T) {
+	tests := []struct {
+		every int
+		want  []int
+	}{
+		{0, []int{0, 1, 2, 3, 4}},
+		{1, []int{0, 1, 2, 3, 4}},
+		{2, []int{0, 2, 4}},
+		{3, []int{0, 3, 4}}, // the final report is never throttled
+		{10, []int{0, 4}},
+	}
+	for _, tt := range tests {
+		var got []int
+		progress := func(done, total int) {
+			if total != len(sampleItems()) {
+				t.Errorf("every %d: total = %d, want %d", tt.every, total, len(sampleItems()))
+			}
+			got = append(got, done)
+		}
+		_, err := New(datahandler.NewMemoryStore(sampleItems()), itemprocessor.NewPassthrough(itemprocessor.WithSilent())).
+			WithLogger(discardLogger).WithProgress(progress).WithProgressEvery(tt.every).Run(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("every %d: progress reported %v, want %v", tt.every, got, tt.want)
+		}
+	}
+}
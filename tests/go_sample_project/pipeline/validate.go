@@ -0,0 +1,97 @@
+// tests/sample_project2/pipeline/validate.go
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"sourcelens/sampleproject2/datahandler"
+	"sourcelens/sampleproject2/itemprocessor"
+	"sourcelens/sampleproject2/models"
+)
+
+// Stages reported in ValidationIssue.Stage.
+const (
+	StageSource     = "source"     // The data source is unreachable.
+	StageLoad       = "load"       // Loading or decoding the items failed, or too few loaded.
+	StageCheckpoint = "checkpoint" // The WithCheckpoint file cannot be read.
+	StageItem       = "item"       // The item fails models.Validate.
+	StageProcessor  = "processor"  // The processor would reject the item.
+)
+
+// ValidationIssue is one problem found by DryValidate.
+type ValidationIssue struct {
+	Stage  string
+	ItemID int // 0 for problems not tied to a single item.
+	Err    error
+}
+
+// String returns a one-line description of the issue.
+func (v ValidationIssue) String() string {
+	if v.Stage == StageItem || v.Stage == StageProcessor {
+		return fmt.Sprintf("%s: item %d: %v", v.Stage, v.ItemID, v.Err)
+	}
+	return fmt.Sprintf("%s: %v", v.Stage, v.Err)
+}
+
+// DryValidate is a preflight for Run: it checks the data source, loads every
+// item, validates each with models.Validate and, for the items Run would
+// process, asks the processor's ValidateItem when it implements
+// itemprocessor.ItemValidator. When the store implements
+// datahandler.UnvalidatedLoader the load skips validation, so every invalid
+// item is reported, even in LenientMode, alongside the processor's verdicts. It never processes or saves items and writes
+// no checkpoint, so it has no side effects beyond reading the data.
+// All problems are returned as issues, in the order found; a source or load
+// failure ends the check early since there are no items to inspect. An empty
+// result means Run should succeed. The error is non-nil only when ctx ends.
+func (p *Pipeline) DryValidate(ctx context.Context) ([]ValidationIssue, error) {
+	var issues []ValidationIssue
+	if pinger, ok := p.store.(datahandler.Pinger); ok {
+		if err := pinger.Ping(); err != nil {
+			return append(issues, ValidationIssue{Stage: StageSource, Err: err}), nil
+		}
+	}
+	items, err := p.loadUnvalidated(ctx)
+	if ctx.Err() != nil {
+		return issues, ctx.Err()
+	}
+	if err != nil {
+		return append(issues, ValidationIssue{Stage: StageLoad, Err: err}), nil
+	}
+	if len(items) < p.minItems {
+		err := fmt.Errorf("%w: got %d, want at least %d", ErrTooFewItems, len(items), p.minItems)
+		issues = append(issues, ValidationIssue{Stage: StageLoad, Err: err})
+	}
+	if p.checkpointPath != "" {
+		if _, _, err := readCheckpoint(p.checkpointPath); err != nil {
+			issues = append(issues, ValidationIssue{Stage: StageCheckpoint, Err: err})
+		}
+	}
+
+	validator, _ := p.proc.(itemprocessor.ItemValidator)
+	for i := range items {
+		if err := ctx.Err(); err != nil {
+			return issues, err
+		}
+		item := &items[i]
+		if err := models.Validate(*item); err != nil {
+			issues = append(issues, ValidationIssue{Stage: StageItem, ItemID: item.ItemID, Err: err})
+		}
+		if validator == nil || !p.selected(*item) {
+			continue
+		}
+		if err := validator.ValidateItem(item); err != nil {
+			issues = append(issues, ValidationIssue{Stage: StageProcessor, ItemID: item.ItemID, Err: err})
+		}
+	}
+	p.logger.Info("Dry validation finished", "items", len(items), "issues", len(issues))
+	return issues, nil
+}
+
+// loadUnvalidated loads the items without models.Validate when the store
+// supports it, falling back to load.
+func (p *Pipeline) loadUnvalidated(ctx context.Context) ([]models.Item, error) {
+	if loader, ok := p.store.(datahandler.UnvalidatedLoader); ok {
+		return loader.LoadItemsUnvalidated(ctx)
+	}
+	return p.load(ctx)
+}
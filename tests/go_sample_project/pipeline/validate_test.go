@@ -0,0 +1,50 @@
+// tests/sample_project2/pipeline/validate_test.go
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sourcelens/sampleproject2/datahandler"
+	"sourcelens/sampleproject2/itemprocessor"
+	"sourcelens/sampleproject2/models"
+	"testing"
+)
+
+func TestDryValidateReportsItemAndProcessorIssues(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "items.json")
+	data := `[
+		{"ItemID": 1, "Name": "Gadget Alpha", "Value": 150.75},
+		{"ItemID": 2, "Name": "", "Value": 85},
+		{"ItemID": 3, "Name": "Thingamajig Gamma", "Value": 500}
+	]`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	tooBig := errors.New("value too large")
+	proc := itemprocessor.NewItemProcessor(100, itemprocessor.WithSilent(), itemprocessor.WithValidator(func(item *models.Item) error {
+		if item.Value > 400 {
+			return tooBig
+		}
+		return nil
+	}))
+
+	for _, lenient := range []bool{false, true} {
+		store := datahandler.NewDataHandler(path, datahandler.WithLogger(discardLogger))
+		store.LenientMode = lenient
+		issues, err := New(store, proc).WithLogger(discardLogger).DryValidate(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got [][2]any
+		for _, issue := range issues {
+			got = append(got, [2]any{issue.Stage, issue.ItemID})
+		}
+		want := [][2]any{{StageItem, 2}, {StageProcessor, 3}}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("LenientMode %v: issues %v, want %v", lenient, issues, want)
+		}
+	}
+}
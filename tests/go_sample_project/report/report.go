@@ -0,0 +1,136 @@
+// tests/sample_project2/report/report.go
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sourcelens/sampleproject2/itemprocessor"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Supported report formats.
+const (
+	FormatText     = "text"
+	FormatJSON     = "json"
+	FormatMarkdown = "markdown"
+)
+
+// Formats lists the formats accepted by Write.
+var Formats = []string{FormatText, FormatJSON, FormatMarkdown}
+
+// summary is the JSON shape of a report.
+type summary struct {
+	TotalItems        int      `json:"total_items"`
+	ProcessedCount    int      `json:"processed_count"`
+	ExceededThreshold int      `json:"exceeded_threshold"`
+	SumValue          float64  `json:"sum_value"`
+	SavedCount        int      `json:"saved_count"`
+	SkippedCount      int      `json:"skipped_count"`
+	AlreadyProcessed  int      `json:"already_processed_count"`
+	DryRun            bool     `json:"dry_run"`
+	Errors            []string `json:"errors"`
+	MinDurationMS     float64  `json:"min_duration_ms"`
+	AvgDurationMS     float64  `json:"avg_duration_ms"`
+	MaxDurationMS     float64  `json:"max_duration_ms"`
+}
+
+// Write renders a run summary of stats to w as "text" (aligned key/value
+// lines), "json" (a single object) or "markdown" (a two-column table).
+// Per-item errors are listed after the totals. Unknown formats are an error.
+func Write(w io.Writer, stats itemprocessor.Stats, format string) error {
+	switch format {
+	case FormatText:
+		return writeText(w, stats)
+	case FormatJSON:
+		return writeJSON(w, stats)
+	case FormatMarkdown:
+		return writeMarkdown(w, stats)
+	default:
+		return fmt.Errorf("unknown report format %q (want one of %s)", format, strings.Join(Formats, ", "))
+	}
+}
+
+// rows returns the label/value pairs shared by the text and markdown reports.
+func rows(stats itemprocessor.Stats) [][2]string {
+	return [][2]string{
+		{"Total items", strconv.Itoa(stats.TotalItems)},
+		{"Processed", strconv.Itoa(stats.ProcessedCount)},
+		{"Exceeded threshold", strconv.Itoa(stats.ExceededThreshold)},
+		{"Sum of values", strconv.FormatFloat(stats.SumValue, 'f', 2, 64)},
+		{"Saved", strconv.Itoa(stats.SavedCount)},
+		{"Skipped", strconv.Itoa(stats.SkippedCount)},
+		{"Already processed", strconv.Itoa(stats.AlreadyProcessedCount)},
+		{"Dry run", strconv.FormatBool(stats.DryRun)},
+		{"Errors", strconv.Itoa(len(stats.Errors))},
+		{"Min duration", stats.MinDuration.String()},
+		{"Avg duration", stats.AvgDuration().String()},
+		{"Max duration", stats.MaxDuration.String()},
+	}
+}
+
+func writeText(w io.Writer, stats itemprocessor.Stats) error {
+	var b strings.Builder
+	for _, row := range rows(stats) {
+		fmt.Fprintf(&b, "%-20s %s\n", row[0]+":", row[1])
+	}
+	for _, err := range stats.Errors {
+		fmt.Fprintf(&b, "  - %v\n", err)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeJSON(w io.Writer, stats itemprocessor.Stats) error {
+	s := summary{
+		TotalItems:        stats.TotalItems,
+		ProcessedCount:    stats.ProcessedCount,
+		ExceededThreshold: stats.ExceededThreshold,
+		SumValue:          stats.SumValue,
+		SavedCount:        stats.SavedCount,
+		SkippedCount:      stats.SkippedCount,
+		AlreadyProcessed:  stats.AlreadyProcessedCount,
+		DryRun:            stats.DryRun,
+		Errors:            make([]string, 0, len(stats.Errors)),
+		MinDurationMS:     milliseconds(stats.MinDuration),
+		AvgDurationMS:     milliseconds(stats.AvgDuration()),
+		MaxDurationMS:     milliseconds(stats.MaxDuration),
+	}
+	for _, err := range stats.Errors {
+		s.Errors = append(s.Errors, err.Error())
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(s); err != nil {
+		return fmt.Errorf("failed to encode report: %w", err)
+	}
+	return nil
+}
+
+// milliseconds converts d to fractional milliseconds for the JSON report.
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+func writeMarkdown(w io.Writer, stats itemprocessor.Stats) error {
+	var b strings.Builder
+	b.WriteString("| Metric | Value |\n|---|---:|\n")
+	for _, row := range rows(stats) {
+		fmt.Fprintf(&b, "| %s | %s |\n", row[0], row[1])
+	}
+	if len(stats.Errors) > 0 {
+		b.WriteString("\n**Errors**\n\n")
+		for _, err := range stats.Errors {
+			fmt.Fprintf(&b, "- %s\n", escapeMarkdown(err.Error()))
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// escapeMarkdown neutralizes characters that would break a list item or table.
+func escapeMarkdown(s string) string {
+	return strings.NewReplacer("|", `\|`, "\n", " ", "*", `\*`, "_", `\_`).Replace(s)
+}